@@ -3,6 +3,7 @@ package flag
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // Argument is used for config and returning parsed flags
@@ -11,32 +12,82 @@ type Argument struct {
 	Name string `json:"name"`
 	// Rules for parsing argument values
 	Type ArgType `json:"type,omitempty"`
-	// Throws error if required and not provided
+	// Throws error if required and not provided, or prompts if interactive
 	Required bool `json:"required,omitempty"`
+	// Choices restricts values to the given set, prompted as a menu if interactive
+	Choices []string `json:"choices,omitempty"`
+	// Details regarding argument usage
+	Help string `json:"help,omitempty"`
 
 	// Populated value for argument
 	Value interface{} `json:"value,omitempty"`
 }
 
-// Parse attempts to set the given value for the given argument. Returns false if it does not meet type criteria
+// Parse attempts to set the given value for the given argument. Returns error if it does not meet type criteria
+// Variadic types (STRINGS, INTS, MAP, INTMAP, BOOLMAP) append to the existing value, so may be parsed repeatedly
 func (arg *Argument) Parse(value string) (err error) {
+	if !isChoice(value, arg.Choices) {
+		return fmt.Errorf("Invalid value encountered. Cannot set <" + value + "> for argument <" + arg.Name + "> - expected one of: " + strings.Join(arg.Choices, ", "))
+	}
+
 	switch arg.Type {
 	case DEFAULT:
 		// String
 		arg.Value = value
-		return
 	case BOOL:
-		// Existence is sufficient
-		arg.Value = true
-		return
+		val, err := parseBool(value)
+		if err != nil {
+			return fmt.Errorf("Invalid value encountered. Cannot set <" + value + "> for BOOL type argument <" + arg.Name + ">")
+		}
+		arg.Value = val
 	case INT:
-		if val, err := strconv.Atoi(value); err == nil {
-			arg.Value = val
-		} else {
+		val, err := strconv.Atoi(value)
+		if err != nil {
 			return fmt.Errorf("Invalid value encountered. Cannot set <" + value + "> for INT type argument <" + arg.Name + ">")
 		}
-		return
+		arg.Value = val
+	case COUNT:
+		val, err := strconv.Atoi(value)
+		if err != nil || val < 0 {
+			return fmt.Errorf("Invalid value encountered. Cannot set <" + value + "> for COUNT type argument <" + arg.Name + ">")
+		}
+		arg.Value = val
+	case STRINGS:
+		vals, _ := arg.Value.([]string)
+		arg.Value = append(vals, value)
+	case INTS:
+		val, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("Invalid value encountered. Cannot set <" + value + "> for INTS type argument <" + arg.Name + ">")
+		}
+		vals, _ := arg.Value.([]int)
+		arg.Value = append(vals, val)
+	case MAP, INTMAP, BOOLMAP:
+		val, err := addPair(arg.Type, arg.Value, value)
+		switch err {
+		case nil:
+			arg.Value = val
+		case errMalformedPair:
+			return fmt.Errorf("Invalid value encountered. Cannot set <" + value + "> for " + string(arg.Type) + " argument <" + arg.Name + "> - expected key=value")
+		case errDuplicateKey:
+			return fmt.Errorf("Redundant value encountered. Cannot set <" + value + "> for " + string(arg.Type) + " argument <" + arg.Name + "> - already contains key: " + pairKey(value))
+		default:
+			return fmt.Errorf("Invalid value encountered. Cannot set <" + value + "> for " + string(arg.Type) + " argument <" + arg.Name + ">")
+		}
+	default:
+		return fmt.Errorf("Invalid type encountered. Cannot set <" + value + "> for unknown type of argument <" + arg.Name + ">")
 	}
 
-	return fmt.Errorf("Invalid value encountered. Cannot set <" + value + "> for " + string(arg.Type) + " argument <" + arg.Name + ">")
+	return
+}
+
+// instance returns a new argument with a copy of the definition, for populating parsed values
+func (arg *Argument) instance() *Argument {
+	return &Argument{
+		Name:     arg.Name,
+		Type:     arg.Type,
+		Required: arg.Required,
+		Choices:  copyStrings(arg.Choices),
+		Help:     arg.Help,
+	}
 }
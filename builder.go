@@ -0,0 +1,360 @@
+package flag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandBuilder adds a command to Parg by chaining, ie:
+// `p.Command("sync").Summary("Sync modules").Arg("module").Required().Flag("-b", "-branch").String().Help("Branch to sync").Handler(fn)`
+// Setters apply to the most recently added argument or flag, or to the command before any are added
+// The definition is validated as it is built, the first error is returned by Err() and stops further changes
+type CommandBuilder struct {
+	parg  *Parg
+	index int
+
+	// Cursor for setters, at most one is set
+	argument *Argument
+	flag     *Flag
+
+	err error
+}
+
+// Command returns a builder for the command with action, adding the command if it doesn't exist
+func (p *Parg) Command(action string) *CommandBuilder {
+	b := &CommandBuilder{parg: p}
+	if strings.HasPrefix(action, "-") {
+		b.err = fmt.Errorf("invalid command <" + action + ">: actions cannot start with -")
+		return b
+	}
+
+	for i := range p.AllowedCommands {
+		if p.AllowedCommands[i].Action == action {
+			b.index = i
+			return b
+		}
+	}
+
+	if cmd, ok := p.GetAllowedCommands()[action]; ok {
+		b.err = fmt.Errorf("invalid command <" + action + ">: already an alias of <" + cmd.Action + ">")
+		return b
+	}
+
+	p.AddCommand(Command{Action: action})
+	b.index = len(p.AllowedCommands) - 1
+	return b
+}
+
+// Err returns the first error encountered while building
+func (b *CommandBuilder) Err() error {
+	return b.err
+}
+
+// cmd returns the command being built
+// Commands are looked up by index, as p.AllowedCommands may grow while building
+func (b *CommandBuilder) cmd() *Command {
+	return &b.parg.AllowedCommands[b.index]
+}
+
+// fail records err unless an error was already encountered
+func (b *CommandBuilder) fail(err error) *CommandBuilder {
+	if b.err == nil {
+		b.err = err
+	}
+
+	return b
+}
+
+// Summary sets the command's help details
+func (b *CommandBuilder) Summary(summary string) *CommandBuilder {
+	if b.err == nil {
+		b.cmd().helpDetails = summary
+	}
+
+	return b
+}
+
+// Alias adds alternate names for the command
+func (b *CommandBuilder) Alias(aliases ...string) *CommandBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	cmd := b.cmd()
+	allowedCommands := b.parg.GetAllowedCommands()
+	for _, alias := range aliases {
+		if existing, ok := allowedCommands[alias]; ok {
+			return b.fail(fmt.Errorf("invalid alias <" + alias + "> for command <" + cmd.Action + ">: already used by <" + existing.Action + ">"))
+		}
+
+		if len(alias) == 0 || strings.HasPrefix(alias, "-") {
+			return b.fail(fmt.Errorf("invalid alias <" + alias + "> for command <" + cmd.Action + ">: aliases cannot be empty or start with -"))
+		}
+
+		cmd.Aliases = append(cmd.Aliases, alias)
+	}
+
+	return b
+}
+
+// Arg adds an optional DEFAULT argument, and moves the cursor to it
+// Only the last argument may be variadic
+func (b *CommandBuilder) Arg(name string) *CommandBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	cmd := b.cmd()
+	if len(name) == 0 {
+		return b.fail(fmt.Errorf("invalid argument for command <" + cmd.Action + ">: name cannot be empty"))
+	}
+
+	for _, argument := range cmd.Arguments {
+		if argument.Name == name {
+			return b.fail(fmt.Errorf("invalid argument <" + name + "> for command <" + cmd.Action + ">: already defined"))
+		}
+	}
+
+	if count := len(cmd.Arguments); count > 0 && cmd.Arguments[count-1].Type.isVariadic() {
+		return b.fail(fmt.Errorf("invalid argument <" + name + "> for command <" + cmd.Action + ">: variadic argument <" + cmd.Arguments[count-1].Name + "> must be last"))
+	}
+
+	b.argument = &Argument{Name: name}
+	b.flag = nil
+	cmd.Arguments = append(cmd.Arguments, b.argument)
+	return b
+}
+
+// Flag adds an optional DEFAULT flag scoped to the command, and moves the cursor to it
+// The first identifier is used as the flag's name
+func (b *CommandBuilder) Flag(identifiers ...string) *CommandBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	cmd := b.cmd()
+	if len(identifiers) == 0 {
+		return b.fail(fmt.Errorf("invalid flag for command <" + cmd.Action + ">: at least one identifier is required"))
+	}
+
+	allowedFlags := withCommandFlags(cmd, b.parg.GetGlobalFlags())
+	for _, identifier := range identifiers {
+		if len(identifier) < 2 || !strings.HasPrefix(identifier, "-") {
+			return b.fail(fmt.Errorf("invalid flag identifier <" + identifier + "> for command <" + cmd.Action + ">: identifiers must start with -"))
+		}
+
+		if existing, ok := allowedFlags[identifier]; ok {
+			return b.fail(fmt.Errorf("invalid flag identifier <" + identifier + "> for command <" + cmd.Action + ">: already used by <" + existing.Name + ">"))
+		}
+	}
+
+	b.flag = &Flag{Name: identifiers[0], Identifiers: identifiers}
+	b.argument = nil
+	if cmd.Flags == nil {
+		cmd.Flags = map[string]*Flag{}
+	}
+	cmd.Flags[b.flag.Name] = b.flag
+	return b
+}
+
+// Required marks the current argument or flag as required
+// Required arguments cannot follow optional arguments
+func (b *CommandBuilder) Required() *CommandBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	switch {
+	case b.flag != nil:
+		b.flag.Required = true
+	case b.argument != nil:
+		for _, argument := range b.cmd().Arguments {
+			if argument == b.argument {
+				break
+			}
+
+			if !argument.Required {
+				return b.fail(fmt.Errorf("invalid argument <" + b.argument.Name + "> for command <" + b.cmd().Action + ">: required arguments cannot follow optional argument <" + argument.Name + ">"))
+			}
+		}
+
+		b.argument.Required = true
+	default:
+		return b.fail(fmt.Errorf("invalid definition for command <" + b.cmd().Action + ">: Required() must follow Arg() or Flag()"))
+	}
+
+	return b
+}
+
+// Type sets the type of the current argument or flag
+func (b *CommandBuilder) Type(argType ArgType) *CommandBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if !argType.isKnown() {
+		return b.fail(fmt.Errorf("invalid definition for command <" + b.cmd().Action + ">: unknown type " + string(argType)))
+	}
+
+	switch {
+	case b.flag != nil:
+		if b.flag.Default != nil && !isValue(argType, b.flag.Default) {
+			return b.fail(fmt.Errorf("invalid flag <%s> for command <%s>: default is %T, not %s", b.flag.Name, b.cmd().Action, b.flag.Default, valueType(argType)))
+		}
+
+		b.flag.Type = argType
+	case b.argument != nil:
+		b.argument.Type = argType
+	default:
+		return b.fail(fmt.Errorf("invalid definition for command <" + b.cmd().Action + ">: Type() must follow Arg() or Flag()"))
+	}
+
+	return b
+}
+
+// String sets the type of the current argument or flag to DEFAULT
+func (b *CommandBuilder) String() *CommandBuilder {
+	return b.Type(DEFAULT)
+}
+
+// Strings sets the type of the current argument or flag to STRINGS
+func (b *CommandBuilder) Strings() *CommandBuilder {
+	return b.Type(STRINGS)
+}
+
+// Int sets the type of the current argument or flag to INT
+func (b *CommandBuilder) Int() *CommandBuilder {
+	return b.Type(INT)
+}
+
+// Ints sets the type of the current argument or flag to INTS
+func (b *CommandBuilder) Ints() *CommandBuilder {
+	return b.Type(INTS)
+}
+
+// Bool sets the type of the current argument or flag to BOOL
+func (b *CommandBuilder) Bool() *CommandBuilder {
+	return b.Type(BOOL)
+}
+
+// Count sets the type of the current argument or flag to COUNT
+func (b *CommandBuilder) Count() *CommandBuilder {
+	return b.Type(COUNT)
+}
+
+// Map sets the type of the current argument or flag to MAP
+func (b *CommandBuilder) Map() *CommandBuilder {
+	return b.Type(MAP)
+}
+
+// IntMap sets the type of the current argument or flag to INTMAP
+func (b *CommandBuilder) IntMap() *CommandBuilder {
+	return b.Type(INTMAP)
+}
+
+// BoolMap sets the type of the current argument or flag to BOOLMAP
+func (b *CommandBuilder) BoolMap() *CommandBuilder {
+	return b.Type(BOOLMAP)
+}
+
+// Help sets help for the current argument or flag, or the command's help details before any are added
+func (b *CommandBuilder) Help(help string) *CommandBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	switch {
+	case b.flag != nil:
+		b.flag.Help = help
+	case b.argument != nil:
+		b.argument.Help = help
+	default:
+		b.cmd().helpDetails = help
+	}
+
+	return b
+}
+
+// Choices restricts values of the current argument or flag to the given set
+func (b *CommandBuilder) Choices(choices ...string) *CommandBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	switch {
+	case b.flag != nil:
+		b.flag.Choices = choices
+	case b.argument != nil:
+		b.argument.Choices = choices
+	default:
+		return b.fail(fmt.Errorf("invalid definition for command <" + b.cmd().Action + ">: Choices() must follow Arg() or Flag()"))
+	}
+
+	return b
+}
+
+// Default sets the default value of the current flag, displayed in help
+// The value must have the type parsed for the flag, ie: int for INT flags, so set the type first
+func (b *CommandBuilder) Default(value interface{}) *CommandBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if b.flag == nil {
+		return b.fail(fmt.Errorf("invalid definition for command <" + b.cmd().Action + ">: Default() must follow Flag()"))
+	}
+
+	if value != nil && !isValue(b.flag.Type, value) {
+		return b.fail(fmt.Errorf("invalid flag <%s> for command <%s>: default is %T, not %s", b.flag.Name, b.cmd().Action, value, valueType(b.flag.Type)))
+	}
+
+	b.flag.Default = value
+	return b
+}
+
+// Hidden omits the current flag from help, or the command before any arguments or flags are added
+// Arguments cannot be hidden
+func (b *CommandBuilder) Hidden() *CommandBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	switch {
+	case b.flag != nil:
+		b.flag.Hidden = true
+	case b.argument != nil:
+		return b.fail(fmt.Errorf("invalid definition for command <" + b.cmd().Action + ">: Hidden() cannot follow Arg()"))
+	default:
+		b.cmd().Hidden = true
+	}
+
+	return b
+}
+
+// Deprecated warns with msg when the current flag is used, or the command before any arguments or flags are added
+// Arguments cannot be deprecated
+func (b *CommandBuilder) Deprecated(msg string) *CommandBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	switch {
+	case b.flag != nil:
+		b.flag.Deprecated = msg
+	case b.argument != nil:
+		return b.fail(fmt.Errorf("invalid definition for command <" + b.cmd().Action + ">: Deprecated() cannot follow Arg()"))
+	default:
+		b.cmd().Deprecated = msg
+	}
+
+	return b
+}
+
+// Handler sets the command's callback on Exec
+func (b *CommandBuilder) Handler(handler Handler) *CommandBuilder {
+	if b.err == nil {
+		b.cmd().handler = handler
+	}
+
+	return b
+}
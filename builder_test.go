@@ -0,0 +1,95 @@
+package flag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestBuilder_Command(context *testing.T) {
+	var synced []string
+	parg := New()
+	parg.AddGlobalFlag(vConfigFlag)
+
+	builder := parg.Command(syncAction).
+		Summary("Sync modules").
+		Alias("s").
+		Arg("modules").Strings().Required().Help("Modules to sync").
+		Flag("-b", "-branch").String().Help("Branch to sync").Default("master").
+		Flag("-retries").Int().Choices("1", "2", "3").
+		Handler(func(cmd *Command) error {
+			modules, err := cmd.ArgStrings("modules")
+			synced = append(modules, cmd.StringFrom("-b"))
+			return err
+		})
+
+	test := simply.Target(builder.Err(), context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	command, err := parg.validate(strings.Split("gomu s mod-common simply -branch main -retries 2 -v", " "))
+
+	test = simply.Target(err, context, "Error should not exist")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.IntFrom("-retries"), context, "Command flag should be parsed")
+	result = test.Equals(2)
+	test.Validate(result)
+
+	command.Exec()
+
+	test = simply.Target(synced, context, "Handler should receive arguments and flags")
+	result = test.Equals([]string{"mod-common", "simply", "main"})
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(command.Help(false), "  <modules>: Modules to sync\n"), context, "Argument help should be printed")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	_, err = parg.validate(strings.Split("gomu sync mod-common -retries=4", " "))
+
+	test = simply.Target(err, context, "Choices should be enforced")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+}
+
+func TestBuilder_Errors(context *testing.T) {
+	parg := New()
+	parg.AddGlobalFlag(vConfigFlag)
+	parg.Command(deployAction)
+
+	builders := map[string]*CommandBuilder{
+		"flag identifiers must start with -":  parg.Command(syncAction).Flag("b"),
+		"flag identifiers must be unique":     parg.Command(syncAction).Flag("-verbose"),
+		"required cannot follow optional":     parg.Command(syncAction).Arg("module").Arg("branch").Required(),
+		"variadic arguments must be last":     parg.Command(syncAction).Arg("modules").Strings().Arg("branch"),
+		"aliases must be unique":              parg.Command(syncAction).Alias(deployAction),
+		"actions cannot start with -":         parg.Command("-sync"),
+		"default must follow a flag":          parg.Command(syncAction).Arg("module").Default("x"),
+		"types must be known":                 parg.Command(syncAction).Arg("module").Type("float"),
+		"arguments must have a unique name":   parg.Command("status").Arg("module").Arg("module"),
+		"errors should stop further building": parg.Command("init").Required().Arg("module"),
+		"arguments cannot be hidden":          parg.Command("list").Arg("module").Hidden(),
+		"arguments cannot be deprecated":      parg.Command("list").Arg("filter").Deprecated("use -filter"),
+		"defaults must match the flag type":   parg.Command("list").Flag("-n").Int().Default("abc"),
+		"types must match the default":        parg.Command("list").Flag("-r").Default("abc").Int(),
+	}
+
+	for msg, builder := range builders {
+		test := simply.Target(builder.Err(), context, "Error should exist: "+msg)
+		result := test.DoesNotEqual(nil)
+		test.Validate(result)
+	}
+
+	test := simply.Target(parg.GetCommand("init").Arguments, context, "Arguments should not be added after an error")
+	result := test.Equals(nil)
+	test.Validate(result)
+
+	list := parg.GetCommand("list")
+
+	test = simply.Target(list.Hidden || len(list.Deprecated) > 0, context, "Misplaced setters should not apply to the command")
+	result = test.Equals(false)
+	test.Validate(result)
+}
@@ -0,0 +1,140 @@
+package flag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Check returns an ErrorList of every conflicting or malformed command, argument and flag definition, or nil if valid
+// Check is run automatically before every parse, and by Compile
+func (p *Parg) Check() error {
+	var errs ErrorList
+	fail := func(msg string) {
+		errs = append(errs, errors.New(msg))
+	}
+
+	// Commands
+	names := map[string]string{}
+	for i := range p.AllowedCommands {
+		cmd := &p.AllowedCommands[i]
+		if strings.HasPrefix(cmd.Action, "-") {
+			fail("invalid command <" + cmd.Action + ">: actions cannot start with -")
+		}
+
+		if existing, ok := names[cmd.Action]; ok {
+			fail("duplicate command <" + cmd.Action + ">: already used by <" + existing + ">")
+		}
+		names[cmd.Action] = cmd.Action
+
+		for _, alias := range cmd.Aliases {
+			if len(alias) == 0 || strings.HasPrefix(alias, "-") {
+				fail("invalid alias <" + alias + "> for command <" + cmd.Action + ">: aliases cannot be empty or start with -")
+			}
+
+			if existing, ok := names[alias]; ok {
+				fail("duplicate alias <" + alias + "> for command <" + cmd.Action + ">: already used by <" + existing + ">")
+			}
+			names[alias] = cmd.Action
+		}
+	}
+
+	// Global flags
+	globals := map[string]*Flag{}
+	globalNames := map[string]*Flag{}
+	for i := range p.GlobalFlags {
+		checkFlag(&p.GlobalFlags[i], "global", globals, globalNames, fail)
+	}
+
+	// Arguments and command flags
+	for i := range p.AllowedCommands {
+		cmd := &p.AllowedCommands[i]
+		checkArguments(cmd, fail)
+		if len(cmd.Flags) == 0 {
+			continue
+		}
+
+		scoped := map[string]*Flag{}
+		for identifier, flag := range globals {
+			scoped[identifier] = flag
+		}
+
+		scopedNames := map[string]*Flag{}
+		for name, flag := range globalNames {
+			scopedNames[name] = flag
+		}
+
+		for _, name := range sortedFlagNames(cmd.Flags) {
+			checkFlag(cmd.Flags[name], "command <"+cmd.Action+">", scoped, scopedNames, fail)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// checkFlag reports a malformed flag, or a name or identifiers already used by another flag in scope
+// Parsed flags are keyed by name, so names must be unique as well. The flag's name and identifiers are added to scope
+// Identifiers must start with - and actions can't, so identifiers never collide with commands
+func checkFlag(flag *Flag, scope string, identifiers map[string]*Flag, names map[string]*Flag, fail func(msg string)) {
+	if existing, ok := names[flag.Name]; ok && existing != flag {
+		fail("duplicate " + scope + " flag name <" + flag.Name + ">: already used by <" + strings.Join(existing.Identifiers, ", ") + ">")
+	}
+	names[flag.Name] = flag
+
+	if len(flag.Identifiers) == 0 {
+		fail("invalid " + scope + " flag <" + flag.Name + ">: at least one identifier is required")
+	}
+
+	if !flag.Type.isKnown() {
+		fail("invalid " + scope + " flag <" + flag.Name + ">: unknown type " + string(flag.Type))
+	} else if flag.Default != nil && !isValue(flag.Type, flag.Default) {
+		fail(fmt.Sprintf("invalid %s flag <%s>: default is %T, not %s", scope, flag.Name, flag.Default, valueType(flag.Type)))
+	}
+
+	for _, identifier := range flag.Identifiers {
+		if len(identifier) < 2 || !strings.HasPrefix(identifier, "-") {
+			fail("invalid " + scope + " flag identifier <" + identifier + ">: identifiers must start with -")
+		}
+
+		if existing, ok := identifiers[identifier]; ok && existing != flag {
+			fail("duplicate " + scope + " flag identifier <" + identifier + ">: already used by <" + existing.Name + ">")
+		}
+		identifiers[identifier] = flag
+	}
+}
+
+// checkArguments reports malformed arguments, required arguments following optional ones,
+// and variadic arguments which aren't last
+func checkArguments(cmd *Command, fail func(msg string)) {
+	names := map[string]bool{}
+	for i, argument := range cmd.Arguments {
+		prefix := "invalid argument <" + argument.Name + "> for command <" + cmd.Action + ">: "
+		if len(argument.Name) == 0 {
+			fail(prefix + "name cannot be empty")
+		} else if names[argument.Name] {
+			fail(prefix + "already defined")
+		}
+		names[argument.Name] = true
+
+		if !argument.Type.isKnown() {
+			fail(prefix + "unknown type " + string(argument.Type))
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		previous := cmd.Arguments[i-1]
+		if argument.Required && !previous.Required {
+			fail(prefix + "required arguments cannot follow optional argument <" + previous.Name + ">")
+		}
+
+		if previous.Type.isVariadic() {
+			fail(prefix + "variadic argument <" + previous.Name + "> must be last")
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package flag
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestCheck(context *testing.T) {
+	parg := New()
+	parg.AddCommand(Command{Action: syncAction, Aliases: []string{"s"}})
+	parg.AddCommand(Command{Action: syncAction})
+	parg.AddCommand(Command{
+		Action:  deployAction,
+		Aliases: []string{"s"},
+		Arguments: []*Argument{
+			{Name: "modules", Type: STRINGS},
+			{Name: "env", Required: true},
+		},
+		Flags: map[string]*Flag{
+			"-b": {Name: "-b", Identifiers: []string{"-b"}},
+		},
+	})
+	parg.AddGlobalFlag(branchConfigFlag)
+	parg.AddGlobalFlag(Flag{Name: "-n", Identifiers: []string{"-n", "sync"}, Type: "float"})
+	parg.AddGlobalFlag(Flag{Name: "-n", Identifiers: []string{"-x"}, Type: INT, Default: "abc"})
+
+	err := parg.Check()
+
+	test := simply.Target(strings.Split(err.Error(), "\n"), context, "Every problem should be reported")
+	result := test.Equals([]string{
+		"duplicate command <sync>: already used by <sync>",
+		"duplicate alias <s> for command <deploy>: already used by <sync>",
+		"invalid global flag <-n>: unknown type float",
+		"invalid global flag identifier <sync>: identifiers must start with -",
+		"duplicate global flag name <-n>: already used by <-n, sync>",
+		"invalid global flag <-n>: default is string, not int",
+		"invalid argument <env> for command <deploy>: required arguments cannot follow optional argument <modules>",
+		"invalid argument <env> for command <deploy>: variadic argument <modules> must be last",
+		"duplicate command <deploy> flag name <-b>: already used by <-b, -branch>",
+		"duplicate command <deploy> flag identifier <-b>: already used by <-b>",
+	})
+	test.Validate(result)
+
+	var list ErrorList
+	test = simply.Target(errors.As(err, &list) && len(list) == 10, context, "Check should return an ErrorList")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	_, err = parg.validate(strings.Split("gomu sync", " "))
+
+	test = simply.Target(err, context, "Check should run before parsing")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+
+	parg.SetCommands([]Command{{Action: syncAction}})
+	parg.SetGlobalFlags([]Flag{branchConfigFlag})
+
+	_, err = parg.validate(strings.Split("gomu sync", " "))
+
+	test = simply.Target(err, context, "Check should run again after changes")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+}
@@ -0,0 +1,121 @@
+package flag
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func collectParg() *Parg {
+	parg := New()
+	parg.CollectErrors = true
+	parg.AddCommand(Command{Action: syncAction, Arguments: []*Argument{{Name: "module", Required: true}}})
+	parg.AddGlobalFlag(Flag{Name: bFlagName, Identifiers: []string{bFlagName}, Choices: []string{"main", "dev"}})
+	parg.AddGlobalFlag(Flag{Name: "-retries", Identifiers: []string{"-retries"}, Type: INT})
+	parg.AddGlobalFlag(Flag{Name: "-env", Identifiers: []string{"-env"}, Required: true})
+	return parg
+}
+
+// positions returns the position of each ParseError in err
+func positions(err error) (positions []int) {
+	var list ErrorList
+	if !errors.As(err, &list) {
+		return
+	}
+
+	for _, err := range list {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			positions = append(positions, parseErr.Position)
+		}
+	}
+
+	return
+}
+
+func TestCollectErrors(context *testing.T) {
+	parg := collectParg()
+
+	command, err := parg.validate(strings.Split("gomu sync mod-common -x -b=feature -retries=abc extra", " "))
+
+	test := simply.Target(command, context, "Command should not exist")
+	result := simply.Assert(test).Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(positions(err), context, "Every problem should be reported with its position")
+	result = test.Equals([]int{3, 4, 5, 6, 7})
+	test.Validate(result)
+
+	test = simply.Target(strings.Split(err.Error(), "\n")[0], context, "Problems should include position and token")
+	result = test.Equals("position 3 <-x>: invalid flag <-x> encountered")
+	test.Validate(result)
+
+	kinds := []error{ErrInvalidFlag, ErrInvalidValue, ErrArgumentCount, ErrMissingRequired}
+	for _, kind := range kinds {
+		test = simply.Target(errors.Is(err, kind), context, "Error should be "+kind.Error())
+		result = test.Equals(true)
+		test.Validate(result)
+	}
+
+	test = simply.Target(errors.Is(err, ErrInvalidCommand), context, "Error should not be "+ErrInvalidCommand.Error())
+	result = test.Equals(false)
+	test.Validate(result)
+}
+
+func TestCollectErrors_FlagValues(context *testing.T) {
+	parg := collectParg()
+	parg.AddGlobalFlag(Flag{Name: "-n", Identifiers: []string{"-n"}, Type: INT})
+
+	_, err := parg.validate(strings.Split("gomu sync -b -x foo -n abc", " "))
+
+	test := simply.Target(positions(err), context, "Missing and invalid flag values should be reported")
+	result := test.Equals([]int{2, 3, 6, 7})
+	test.Validate(result)
+
+	var list ErrorList
+	errors.As(err, &list)
+
+	test = simply.Target(list[0].Error(), context, "Missing value should be reported at the flag")
+	result = test.Equals("missing value for flag <-b>")
+	test.Validate(result)
+
+	var parseErr *ParseError
+	errors.As(list[2], &parseErr)
+
+	test = simply.Target(parseErr.Expects, context, "Invalid value should include the expectation")
+	result = test.Equals("a single integer")
+	test.Validate(result)
+
+	_, err = parg.validate(strings.Split("gomu sync mod-common -env dev -retries", " "))
+
+	test = simply.Target(positions(err), context, "Missing value should be reported at the end of the line")
+	result = test.Equals([]int{5})
+	test.Validate(result)
+}
+
+func TestCollectErrors_InvalidCommand(context *testing.T) {
+	parg := collectParg()
+
+	_, err := parg.validate(strings.Split("gomu synk mod-common -x -env dev", " "))
+
+	test := simply.Target(positions(err), context, "Flags should still be checked after an invalid command")
+	result := test.Equals([]int{1, 3})
+	test.Validate(result)
+
+	test = simply.Target(errors.Is(err, ErrInvalidCommand), context, "Error should be "+ErrInvalidCommand.Error())
+	result = test.Equals(true)
+	test.Validate(result)
+}
+
+func TestCollectErrors_Disabled(context *testing.T) {
+	parg := collectParg()
+	parg.CollectErrors = false
+
+	_, err := parg.validate(strings.Split("gomu sync mod-common -x -b=feature", " "))
+
+	test := simply.Target(err.Error(), context, "Parsing should stop at the first problem")
+	result := test.Equals("invalid flag <-x> encountered")
+	test.Validate(result)
+}
@@ -2,7 +2,6 @@ package flag
 
 import (
 	"fmt"
-	"os"
 	"strings"
 )
 
@@ -14,13 +13,25 @@ type Command struct {
 	// Action is the parsed primary argument
 	Action string `json:"action"`
 
+	// Aliases are alternate names which also match this command's action
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Hidden commands are parsed but omitted from help
+	Hidden bool `json:"hidden,omitempty"`
+	// Deprecated commands are parsed but warn with this message, ie: "use sync instead"
+	Deprecated string `json:"deprecated,omitempty"`
+
 	// Arguments used for defining config values and for returning optional and required trailing args
 	Arguments []*Argument `json:"arguments,omitempty"`
 
 	// Flags returned by matched action instance
+	// On definitions, flags scoped to the command: allowed only after its action, and checked if required
 	Flags map[string]*Flag `json:"flags,omitempty"`
 
-	handler func(cmd *Command) (err error)
+	handler Handler
+
+	// Pre-run hooks, post-run hooks and middleware
+	hooks hooks
 
 	// Details regarding command usage
 	helpDetails string
@@ -39,6 +50,16 @@ func NewCommand() (cmd *Command) {
 
 // Help will return all available commands and flags
 func Help(markdown bool) string {
+	static := getStaticParg()
+	if static == nil {
+		static = &Parg{}
+	}
+
+	return static.help(markdown)
+}
+
+// help returns all commands and flags configured on p
+func (p *Parg) help(markdown bool) string {
 	var prefix = ""
 	if markdown {
 		prefix = "#"
@@ -48,20 +69,35 @@ func Help(markdown bool) string {
 	var triplePrefix = doublePrefix + prefix
 
 	msg := "\n" + doublePrefix + " Commands\n\n"
-	for _, cmd := range staticParg.AllowedCommands {
-		if strings.TrimSpace(cmd.Action) != "" {
-			msg += fmt.Sprintf("%s %s %s\n  :: ", triplePrefix, os.Args[0], cmd.Action)
-		} else {
-			msg += triplePrefix + " " + os.Args[0] + "\n  :: "
+	for _, cmd := range p.AllowedCommands {
+		if cmd.Hidden {
+			continue
 		}
+
+		// Global flags are listed separately
+		msg += triplePrefix + " " + p.synopsis(&cmd, false) + "\n"
+		if strings.TrimSpace(cmd.Action) != "" && len(cmd.Aliases) > 0 {
+			msg += "  aliases: " + strings.Join(cmd.Aliases, ", ") + "\n"
+		}
+		msg += "  :: "
 		msg += cmd.helpDetails
-		msg += "\n\n"
+		msg += "\n"
+		msg += cmd.argumentsHelp()
+		msg += "\n"
 	}
 
-	if len(staticParg.GlobalFlags) > 0 {
+	helpFlags := p.helpFlags()
+	if len(p.GlobalFlags) > 0 || len(helpFlags) > 0 {
 		msg += doublePrefix + " Flags\n"
-		for _, flag := range staticParg.GlobalFlags {
-			msg += fmt.Sprintf("\n%s %s\n  :: %s\n", triplePrefix, flag.Identifiers, flag.Help)
+		for _, flag := range p.GlobalFlags {
+			if flag.Hidden {
+				continue
+			}
+			msg += fmt.Sprintf("\n%s %s\n  :: %s\n", triplePrefix, flag.helpIdentifiers(), flag.helpText())
+		}
+
+		if len(helpFlags) > 0 {
+			msg += fmt.Sprintf("\n%s %s\n  :: %s\n", triplePrefix, helpFlags, "Show help for a command")
 		}
 	}
 
@@ -70,6 +106,17 @@ func Help(markdown bool) string {
 
 // Help will return a command's help. If help is the command, returns first arg or general help
 func (cmd *Command) Help(markdown bool) string {
+	static := getStaticParg()
+	if static == nil {
+		static = &Parg{}
+	}
+
+	return static.commandHelp(cmd, markdown)
+}
+
+// commandHelp returns cmd's help, using the commands and flags configured on p
+// If help is the command, returns help for the first arg or general help
+func (p *Parg) commandHelp(cmd *Command, markdown bool) string {
 	var prefix = ""
 	if markdown {
 		prefix = "#"
@@ -78,57 +125,61 @@ func (cmd *Command) Help(markdown bool) string {
 	var doublePrefix = prefix + prefix
 	var triplePrefix = doublePrefix + prefix
 
+	// Help for the command named by help's first argument, without changing cmd
+	target := cmd
 	msg := "\n" + doublePrefix + " Command: "
 	if cmd.Action == "help" {
 		if len(cmd.Arguments) == 0 {
 			// Show regular help
 			if len(cmd.Flags) == 0 {
-				return Help(true)
+				return p.help(true)
 			}
 		} else {
-			for _, argCmd := range staticParg.AllowedCommands {
-				if name, ok := cmd.Arguments[0].Value.(string); ok {
-					if argCmd.Action == name {
-						// Show help for this cmd
-						cmd.Action = argCmd.Action
-						cmd.helpDetails = argCmd.helpDetails
-						break
-					}
-				}
-				if argCmd.Action == cmd.Arguments[0].Name {
+			for i := range p.AllowedCommands {
+				argCmd := &p.AllowedCommands[i]
+				name, ok := cmd.Arguments[0].Value.(string)
+				if ok && argCmd.matches(name) || argCmd.matches(cmd.Arguments[0].Name) {
 					// Show help for this cmd
-					cmd.Action = argCmd.Action
-					cmd.helpDetails = argCmd.helpDetails
+					target = argCmd
 					break
 				}
 			}
 		}
 	}
 
-	if cmd.Action == "help" {
+	if target.Action == "help" {
 		if len(cmd.Flags) == 0 {
-			msg = Help(true)
+			msg = p.help(true)
 		} else {
 			msg = ""
 		}
 	} else {
-		msg += cmd.Action + "\n\n"
-		msg += fmt.Sprintf("%s %s %s\n  :: ", triplePrefix, os.Args[0], cmd.Action)
-		msg += cmd.helpDetails
+		msg += target.Action + "\n\n"
+		msg += triplePrefix + " " + p.commandSynopsis(target) + "\n"
+		if len(target.Aliases) > 0 {
+			msg += "  aliases: " + strings.Join(target.Aliases, ", ") + "\n"
+		}
+		msg += "  :: " + target.helpDetails
 		msg += "\n"
+		if definition := p.command(target.Action); definition != nil {
+			msg += definition.argumentsHelp()
+		}
 	}
 
 	if len(cmd.Flags) > 0 {
 		msg += "\n" + doublePrefix + " Flags: "
 		output := ""
 		for _, flag := range cmd.Flags {
+			if flag.Hidden {
+				continue
+			}
 			msg += flag.Name + " "
-			output += fmt.Sprintf("\n%s %s\n  :: %s\n", triplePrefix, flag.Identifiers, flag.Help)
+			output += fmt.Sprintf("\n%s %s\n  :: %s\n", triplePrefix, flag.helpIdentifiers(), flag.helpText())
 		}
 		msg += "\n" + output
 	}
 
-	if cmd.Action == "help" {
+	if target.Action == "help" {
 		if len(cmd.Arguments) > 0 {
 			msg += "\nError parsing arguments: invalid command <" + cmd.Arguments[0].Name + "> encountered"
 		}
@@ -137,13 +188,53 @@ func (cmd *Command) Help(markdown bool) string {
 	return msg
 }
 
-// Exec will run handler
+// argumentsHelp returns a line for each of the command's arguments with help, ie: `  <module>: Module to sync`
+func (cmd *Command) argumentsHelp() (msg string) {
+	for _, argument := range cmd.Arguments {
+		if len(argument.Help) > 0 {
+			msg += "  <" + argument.Name + ">: " + argument.Help + "\n"
+		}
+	}
+
+	return
+}
+
+// matches returns true if name is the command's action or one of its aliases
+func (cmd *Command) matches(name string) bool {
+	if cmd.Action == name {
+		return true
+	}
+
+	for _, alias := range cmd.Aliases {
+		if alias == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Exec will run handler, along with any configured hooks and middleware in order:
+// Parg pre-run, Command pre-run, Parg middleware, Command middleware, handler, Command post-run, Parg post-run
+// Post-run hooks receive the handler's error, and are skipped if a pre-run hook fails
 func (cmd *Command) Exec() (err error) {
 	if cmd.handler == nil {
-		err = fmt.Errorf("unable to exec cmd \"%s\": no handler set", cmd.Action)
+		return fmt.Errorf("unable to exec cmd \"%s\": no handler set", cmd.Action)
 	}
 
-	return cmd.handler(cmd)
+	for _, hook := range cmd.hooks.preRun {
+		if err = hook(cmd); err != nil {
+			return
+		}
+	}
+
+	err = cmd.hooks.wrap(cmd.handler)(cmd)
+
+	for _, hook := range cmd.hooks.postRun {
+		err = hook(cmd, err)
+	}
+
+	return
 }
 
 // Args returns array of arg names
@@ -156,6 +247,153 @@ func (cmd *Command) Args() []string {
 	return args
 }
 
+// Argument returns the parsed argument with the configured name
+func (cmd *Command) Argument(name string) (*Argument, bool) {
+	for _, argument := range cmd.Arguments {
+		if argument.Name == name {
+			return argument, true
+		}
+	}
+
+	return nil, false
+}
+
+// ArgAt returns the parsed argument at index
+func (cmd *Command) ArgAt(index int) (*Argument, bool) {
+	if index < 0 || index >= len(cmd.Arguments) {
+		return nil, false
+	}
+
+	return cmd.Arguments[index], true
+}
+
+// argValue returns the value of the argument with the configured name, or error if not provided
+func (cmd *Command) argValue(name string) (interface{}, error) {
+	argument, ok := cmd.Argument(name)
+	if !ok || argument.Value == nil {
+		return nil, fmt.Errorf("argument <" + name + "> not provided")
+	}
+
+	return argument.Value, nil
+}
+
+// argTypeError returns an error for an argument value which isn't of the expected type
+func argTypeError(name string, value interface{}, expected string) error {
+	return fmt.Errorf("argument <%s> is %T, not %s", name, value, expected)
+}
+
+// ArgString returns the string value of the argument with the configured name
+func (cmd *Command) ArgString(name string) (val string, err error) {
+	value, err := cmd.argValue(name)
+	if err != nil {
+		return
+	}
+
+	val, ok := value.(string)
+	if !ok {
+		err = argTypeError(name, value, "string")
+	}
+	return
+}
+
+// ArgStrings returns the []string value of the argument with the configured name
+func (cmd *Command) ArgStrings(name string) (vals []string, err error) {
+	value, err := cmd.argValue(name)
+	if err != nil {
+		return
+	}
+
+	vals, ok := value.([]string)
+	if !ok {
+		err = argTypeError(name, value, "[]string")
+	}
+	return
+}
+
+// ArgInt returns the int value of the argument with the configured name
+func (cmd *Command) ArgInt(name string) (val int, err error) {
+	value, err := cmd.argValue(name)
+	if err != nil {
+		return
+	}
+
+	val, ok := value.(int)
+	if !ok {
+		err = argTypeError(name, value, "int")
+	}
+	return
+}
+
+// ArgInts returns the []int value of the argument with the configured name
+func (cmd *Command) ArgInts(name string) (vals []int, err error) {
+	value, err := cmd.argValue(name)
+	if err != nil {
+		return
+	}
+
+	vals, ok := value.([]int)
+	if !ok {
+		err = argTypeError(name, value, "[]int")
+	}
+	return
+}
+
+// ArgBool returns the bool value of the argument with the configured name
+func (cmd *Command) ArgBool(name string) (val bool, err error) {
+	value, err := cmd.argValue(name)
+	if err != nil {
+		return
+	}
+
+	val, ok := value.(bool)
+	if !ok {
+		err = argTypeError(name, value, "bool")
+	}
+	return
+}
+
+// ArgMap returns the map[string]string value of the argument with the configured name
+func (cmd *Command) ArgMap(name string) (vals map[string]string, err error) {
+	value, err := cmd.argValue(name)
+	if err != nil {
+		return
+	}
+
+	vals, ok := value.(map[string]string)
+	if !ok {
+		err = argTypeError(name, value, "map[string]string")
+	}
+	return
+}
+
+// ArgIntMap returns the map[string]int value of the argument with the configured name
+func (cmd *Command) ArgIntMap(name string) (vals map[string]int, err error) {
+	value, err := cmd.argValue(name)
+	if err != nil {
+		return
+	}
+
+	vals, ok := value.(map[string]int)
+	if !ok {
+		err = argTypeError(name, value, "map[string]int")
+	}
+	return
+}
+
+// ArgBoolMap returns the map[string]bool value of the argument with the configured name
+func (cmd *Command) ArgBoolMap(name string) (vals map[string]bool, err error) {
+	value, err := cmd.argValue(name)
+	if err != nil {
+		return
+	}
+
+	vals, ok := value.(map[string]bool)
+	if !ok {
+		err = argTypeError(name, value, "map[string]bool")
+	}
+	return
+}
+
 // StringsFrom parses []string from flags["flagIdentifier"]
 func (cmd *Command) StringsFrom(flagIdentifier string) (vals []string) {
 	flag, ok := cmd.Flags[flagIdentifier]
@@ -225,3 +463,59 @@ func (cmd *Command) BoolFrom(flagIdentifier string) (val bool) {
 	}
 	return
 }
+
+// CountFrom parses occurrence count from flags["flagIdentifier"]
+func (cmd *Command) CountFrom(flagIdentifier string) (val int) {
+	flag, ok := cmd.Flags[flagIdentifier]
+	if !ok {
+		return
+	}
+
+	val, ok = flag.Value.(int)
+	if !ok {
+		return
+	}
+	return
+}
+
+// MapFrom parses map[string]string from flags["flagIdentifier"]
+func (cmd *Command) MapFrom(flagIdentifier string) (vals map[string]string) {
+	flag, ok := cmd.Flags[flagIdentifier]
+	if !ok {
+		return
+	}
+
+	vals, ok = flag.Value.(map[string]string)
+	if !ok {
+		return
+	}
+	return
+}
+
+// IntMapFrom parses map[string]int from flags["flagIdentifier"]
+func (cmd *Command) IntMapFrom(flagIdentifier string) (vals map[string]int) {
+	flag, ok := cmd.Flags[flagIdentifier]
+	if !ok {
+		return
+	}
+
+	vals, ok = flag.Value.(map[string]int)
+	if !ok {
+		return
+	}
+	return
+}
+
+// BoolMapFrom parses map[string]bool from flags["flagIdentifier"]
+func (cmd *Command) BoolMapFrom(flagIdentifier string) (vals map[string]bool) {
+	flag, ok := cmd.Flags[flagIdentifier]
+	if !ok {
+		return
+	}
+
+	vals, ok = flag.Value.(map[string]bool)
+	if !ok {
+		return
+	}
+	return
+}
@@ -0,0 +1,151 @@
+package flag
+
+import "os"
+
+// Parser is a compiled, immutable copy of a Parg's definitions, with lookup tables precomputed for parsing
+// Parser is safe for concurrent use, as parsing only reads the tables and creates new commands, arguments and flags
+// Unlike Parg, parsing does not fill flag targets registered by p.String() etc, as they would be shared between goroutines
+type Parser struct {
+	// parg provides definitions and options
+	parg *Parg
+
+	// Global flags by identifier
+	flags map[string]*Flag
+	// Commands by action and alias
+	commands map[string]*Command
+	// Global and command flags by identifier, for commands with flags
+	scoped map[*Command]map[string]*Flag
+	// Identifiers of sensitive flags, for response file expansion
+	sensitive map[string]bool
+
+	// General help, for parsed commands without help details
+	// Rendered on every parse if empty, as for parsers which aren't compiled
+	help string
+}
+
+// Compile checks p's definitions, and returns a parser for a copy of them
+// Changes made to p after compiling do not affect the parser
+func (p *Parg) Compile() (*Parser, error) {
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+
+	c := newParser(p.clone())
+	c.help = c.parg.help(true)
+	return c, nil
+}
+
+// Parse returns the command for argV, which includes the program name as os.Args does
+func (c *Parser) Parse(argV []string) (*Command, error) {
+	return c.parse(argV, false)
+}
+
+// ParseLine tokenizes line with shell quoting rules and returns the command for it
+// The program name is prepended, so line should only contain arguments, ie: `sync -b "JIRA Ticket"`
+func (c *Parser) ParseLine(line string) (*Command, error) {
+	args, err := Tokenize(line)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.parse(append([]string{os.Args[0]}, args...), false)
+}
+
+// newParser returns a parser with lookup tables for p's definitions
+func newParser(p *Parg) *Parser {
+	c := &Parser{
+		parg:      p,
+		flags:     p.GetGlobalFlags(),
+		commands:  p.GetAllowedCommands(),
+		scoped:    map[*Command]map[string]*Flag{},
+		sensitive: p.sensitiveIdentifiers(),
+	}
+
+	for i := range p.AllowedCommands {
+		if cmd := &p.AllowedCommands[i]; len(cmd.Flags) > 0 {
+			c.scoped[cmd] = withCommandFlags(cmd, c.flags)
+		}
+	}
+
+	return c
+}
+
+// clone returns a copy of p, with copies of its definitions
+func (p *Parg) clone() *Parg {
+	c := Parg{
+		PrefixMatching:   p.PrefixMatching,
+		Warnings:         p.Warnings,
+		ResponseFiles:    p.ResponseFiles,
+		Interactive:      p.Interactive,
+		Stdin:            p.Stdin,
+		Prompts:          p.Prompts,
+		Output:           p.Output,
+		HelpFlags:        copyStrings(p.HelpFlags),
+		DisableHelpFlags: p.DisableHelpFlags,
+		CollectErrors:    p.CollectErrors,
+		hooks:            p.hooks.clone(),
+		version:          p.version,
+		versioned:        p.versioned,
+		terminal:         p.terminal,
+	}
+
+	c.AllowedCommands = make([]Command, len(p.AllowedCommands))
+	for i := range p.AllowedCommands {
+		c.AllowedCommands[i] = *p.AllowedCommands[i].clone()
+	}
+
+	c.GlobalFlags = make([]Flag, len(p.GlobalFlags))
+	for i := range p.GlobalFlags {
+		c.GlobalFlags[i] = *p.GlobalFlags[i].clone()
+	}
+
+	return &c
+}
+
+// clone returns a copy of the command definition, with copies of its aliases, arguments and flags
+func (cmd *Command) clone() *Command {
+	c := *cmd
+	c.Aliases = copyStrings(cmd.Aliases)
+
+	if cmd.Arguments != nil {
+		c.Arguments = make([]*Argument, len(cmd.Arguments))
+		for i, argument := range cmd.Arguments {
+			c.Arguments[i] = argument.clone()
+		}
+	}
+
+	if cmd.Flags != nil {
+		c.Flags = make(map[string]*Flag, len(cmd.Flags))
+		for name, flag := range cmd.Flags {
+			c.Flags[name] = flag.clone()
+		}
+	}
+
+	c.hooks = cmd.hooks.clone()
+	return &c
+}
+
+// clone returns a copy of the argument, with a copy of its choices
+func (arg *Argument) clone() *Argument {
+	c := *arg
+	c.Choices = copyStrings(arg.Choices)
+	return &c
+}
+
+// clone returns a copy of the flag, with copies of its identifiers, choices and default
+func (flag *Flag) clone() *Flag {
+	c := *flag
+	c.Identifiers = copyStrings(flag.Identifiers)
+	c.Choices = copyStrings(flag.Choices)
+	c.Default = copyValue(flag.Default)
+	return &c
+}
+
+// clone returns a copy of the hooks
+func (h hooks) clone() hooks {
+	return hooks{
+		preRun:     append([]Handler(nil), h.preRun...),
+		postRun:    append([]PostRunHook(nil), h.postRun...),
+		middleware: append([]Middleware(nil), h.middleware...),
+	}
+}
@@ -0,0 +1,123 @@
+package flag
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestCompile(context *testing.T) {
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(Flag{Name: bFlagName, Identifiers: []string{bFlagName, "-branch"}})
+	branch := parg.String("-n", "name", "", "")
+
+	parser, err := parg.Compile()
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	// Changes after compiling should not affect the parser
+	parg.GlobalFlags[0].Identifiers[1] = "-other"
+	parg.AddAction(deployAction, "")
+
+	command, err := parser.ParseLine("sync -branch main -n 'parg flag'")
+
+	test = simply.Target(err, context, "Error should not exist")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom(bFlagName), context, "Branch should be parsed with compiled identifiers")
+	result = test.Equals("main")
+	test.Validate(result)
+
+	test = simply.Target(*branch, context, "Registered targets should not be filled by a parser")
+	result = test.Equals("")
+	test.Validate(result)
+
+	_, err = parser.Parse(strings.Split("gomu deploy", " "))
+
+	test = simply.Target(err, context, "Commands added after compiling should not be allowed")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+
+	parg.AddAction(syncAction, "")
+	_, err = parg.Compile()
+
+	test = simply.Target(err, context, "Compile should check definitions")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+}
+
+func TestValidate_DirectChanges(context *testing.T) {
+	parg := New()
+	parg.AddAction(syncAction, "")
+
+	_, err := parg.validate(strings.Split("gomu sync", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	parg.AllowedCommands = append(parg.AllowedCommands, Command{Action: deployAction})
+	parg.GlobalFlags = append(parg.GlobalFlags, Flag{Name: bFlagName, Identifiers: []string{bFlagName}})
+
+	command, err := parg.validate(strings.Split("gomu deploy -b main", " "))
+
+	test = simply.Target(err, context, "Definitions changed directly should be used by the next parse")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom(bFlagName), context, "Flag added directly should be parsed")
+	result = test.Equals("main")
+	test.Validate(result)
+}
+
+// benchmarkParg returns a parg with many commands and flags, and a line using several of them
+// The default command provides help details, so general help isn't rendered while parsing
+func benchmarkParg() (*Parg, []string) {
+	parg := New()
+	parg.AddAction("", "")
+	for i := 0; i < 20; i++ {
+		parg.AddAction("command-"+strconv.Itoa(i), "")
+	}
+
+	for i := 0; i < 50; i++ {
+		name := "-flag-" + strconv.Itoa(i)
+		parg.AddGlobalFlag(Flag{Name: name, Identifiers: []string{name, "--" + name[1:]}})
+	}
+
+	return parg, strings.Split("gomu command-19 -flag-49 a --flag-25 b -flag-0 c mod-common", " ")
+}
+
+func BenchmarkParse_Compiled(b *testing.B) {
+	parg, argV := benchmarkParg()
+	parser, err := parg.Compile()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parser.Parse(argV); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParse_Uncompiled(b *testing.B) {
+	// Checks definitions and rebuilds lookup tables on every parse
+	parg, argV := benchmarkParg()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parg.validate(argV); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package flag
+
+import (
+	"strings"
+)
+
+// Complete returns completion candidates for the last of args, from configured commands and flags
+// Args are not expected to include the program name, ie: ["sy"] or ["sync", "-"]
+// Hidden commands and flags are omitted
+func (p *Parg) Complete(args []string) (candidates []string) {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+
+	word := args[len(args)-1]
+	previous := args[:len(args)-1]
+
+	allowedFlags := p.GetGlobalFlags()
+	allowedCommands := p.GetAllowedCommands()
+
+	var command *Command
+	for _, arg := range previous {
+		if cmd, _ := p.matchCommand(arg, allowedCommands); cmd != nil && len(arg) > 0 {
+			// Flags scoped to the command are allowed after it
+			command = cmd
+			allowedFlags = withCommandFlags(cmd, allowedFlags)
+			break
+		}
+	}
+
+	if len(previous) > 0 {
+		// Suggest choices for the preceding flag
+		identifier, _, _ := splitFlag(previous[len(previous)-1])
+		if flag, ok := allowedFlags[identifier]; ok && !flag.Hidden {
+			for _, choice := range flag.Choices {
+				if strings.HasPrefix(choice, word) {
+					candidates = append(candidates, choice)
+				}
+			}
+
+			if len(flag.Choices) > 0 {
+				return unique(candidates)
+			}
+		}
+	}
+
+	if strings.HasPrefix(word, "-") {
+		for _, flag := range allowedFlags {
+			if flag.Hidden {
+				continue
+			}
+
+			for _, identifier := range flag.helpIdentifiers() {
+				if strings.HasPrefix(identifier, word) {
+					candidates = append(candidates, identifier)
+				}
+			}
+		}
+
+		return unique(candidates)
+	}
+
+	if command != nil {
+		// Command already provided
+		return
+	}
+
+	for name, cmd := range allowedCommands {
+		if !cmd.Hidden && len(name) > 0 && strings.HasPrefix(name, word) {
+			candidates = append(candidates, name)
+		}
+	}
+
+	return unique(candidates)
+}
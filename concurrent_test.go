@@ -0,0 +1,146 @@
+package flag
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func concurrentParg() *Parg {
+	parg := New()
+	parg.AddCommand(Command{
+		Action:    syncAction,
+		Aliases:   []string{"s"},
+		Arguments: []*Argument{{Name: "modules", Type: STRINGS, Choices: []string{"mod-common", "simply"}}},
+	})
+	parg.AddAction(deployAction, "Deploy a module")
+	parg.AddGlobalFlag(Flag{Name: bFlagName, Identifiers: []string{bFlagName, "-branch"}, Choices: []string{"main", "dev"}})
+	parg.AddGlobalFlag(Flag{Name: "-label", Identifiers: []string{"-label"}, Type: MAP, Default: map[string]string{"env": "dev"}})
+	return parg
+}
+
+// parseConcurrently parses lines from many goroutines, changing each returned command, and returns the errors
+// Simply is not used within goroutines, as it is not safe for concurrent use
+func parseConcurrently(parse func(line string) (*Command, error), lines []string) (errs []error) {
+	var wg sync.WaitGroup
+	results := make(chan error, len(lines)*20)
+	for i := 0; i < 20; i++ {
+		for _, line := range lines {
+			wg.Add(1)
+			go func(line string) {
+				defer wg.Done()
+				command, err := parse(line)
+				if err != nil {
+					results <- err
+					return
+				}
+
+				// Changing returned commands should not affect definitions or other commands
+				for _, flag := range command.Flags {
+					flag.Identifiers[0] = "-changed"
+					if len(flag.Choices) > 0 {
+						flag.Choices[0] = "changed"
+					}
+					if labels, ok := flag.Default.(map[string]string); ok {
+						labels["env"] = "changed"
+					}
+				}
+
+				for _, argument := range command.Arguments {
+					if len(argument.Choices) > 0 {
+						argument.Choices[0] = "changed"
+					}
+				}
+
+				command.Help(false)
+			}(line)
+		}
+	}
+
+	wg.Wait()
+	close(results)
+	for err := range results {
+		errs = append(errs, err)
+	}
+
+	return
+}
+
+func TestConcurrent_Parg(context *testing.T) {
+	parg := concurrentParg()
+	lines := []string{
+		"sync mod-common simply -b main -label tier=web",
+		"s simply -branch dev",
+		"deploy -label team=core",
+		"deploy --help",
+		"sync -h",
+	}
+
+	errs := parseConcurrently(parg.ParseLine, lines)
+
+	test := simply.Target(errs, context, "Errors should not exist")
+	result := test.Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(parg.GlobalFlags[0].Identifiers, context, "Definition identifiers should not change")
+	result = test.Equals([]string{bFlagName, "-branch"})
+	test.Validate(result)
+
+	test = simply.Target(parg.GlobalFlags[0].Choices, context, "Definition choices should not change")
+	result = test.Equals([]string{"main", "dev"})
+	test.Validate(result)
+
+	test = simply.Target(parg.GlobalFlags[1].Default, context, "Definition default should not change")
+	result = test.Equals(map[string]string{"env": "dev"})
+	test.Validate(result)
+
+	test = simply.Target(parg.AllowedCommands[0].Arguments[0].Choices, context, "Argument choices should not change")
+	result = test.Equals([]string{"mod-common", "simply"})
+	test.Validate(result)
+}
+
+func TestConcurrent_Parser(context *testing.T) {
+	parser, err := concurrentParg().Compile()
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	errs := parseConcurrently(parser.ParseLine, []string{
+		"sync mod-common -b main",
+		"deploy -label team=core",
+	})
+
+	test = simply.Target(errs, context, "Errors should not exist")
+	result = test.Equals(nil)
+	test.Validate(result)
+
+	command, err := parser.ParseLine("sync simply -branch dev")
+
+	test = simply.Target(err, context, "Error should not exist")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom(bFlagName), context, "Parser should be unaffected by changed commands")
+	result = test.Equals("dev")
+	test.Validate(result)
+}
+
+func TestCommand_HelpDoesNotChangeCommand(context *testing.T) {
+	concurrentParg()
+	command := NewCommand()
+	command.Action = "help"
+	command.Arguments = []*Argument{{Name: syncAction, Value: syncAction}}
+
+	help := command.Help(false)
+
+	test := simply.Target(strings.Contains(help, "Command: sync"), context, "Help should be for sync")
+	result := test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(command.Action, context, "Help should not change the command")
+	result = test.Equals("help")
+	test.Validate(result)
+}
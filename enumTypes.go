@@ -18,6 +18,18 @@ const (
 
 	// INTS expects at least 1 or more number arguments
 	INTS = "[]int"
+
+	// MAP expects at least 1 or more key=value string arguments
+	MAP = "map[string]string"
+
+	// INTMAP expects at least 1 or more key=value arguments with number values
+	INTMAP = "map[string]int"
+
+	// BOOLMAP expects at least 1 or more key=value arguments with true/false values
+	BOOLMAP = "map[string]bool"
+
+	// COUNT expects no flag arguments, value is the number of occurrences
+	COUNT = "count"
 )
 
 // Expects returns a string indicating what the type should parse
@@ -31,9 +43,37 @@ func (a ArgType) Expects() string {
 		return "a single integer"
 	case INTS:
 		return "one or more integers"
+	case MAP:
+		return "one or more key=value pairs"
+	case INTMAP:
+		return "one or more key=integer pairs"
+	case BOOLMAP:
+		return "one or more key=true/false pairs"
 	case BOOL:
 		return "no trailing arguments"
+	case COUNT:
+		return "no trailing arguments, may be repeated"
 	default:
 		return "unknown"
 	}
 }
+
+// isVariadic returns true for types which accept multiple values
+func (a ArgType) isVariadic() bool {
+	switch a {
+	case STRINGS, INTS, MAP, INTMAP, BOOLMAP:
+		return true
+	default:
+		return false
+	}
+}
+
+// isKnown returns true for the types defined above
+func (a ArgType) isKnown() bool {
+	switch a {
+	case DEFAULT, BOOL, STRINGS, INT, INTS, MAP, INTMAP, BOOLMAP, COUNT:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+package flag
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidFlag is the kind of ParseError for flags which aren't allowed
+	ErrInvalidFlag = errors.New("invalid flag")
+	// ErrInvalidCommand is the kind of ParseError for unknown, ambiguous or missing commands
+	ErrInvalidCommand = errors.New("invalid command")
+	// ErrInvalidValue is the kind of ParseError for flag and argument values which don't match their type or choices
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrArgumentCount is the kind of ParseError for arguments beyond those configured for the command
+	ErrArgumentCount = errors.New("invalid argument count")
+	// ErrMissingRequired is the kind of ParseError for required arguments and flags which weren't provided
+	ErrMissingRequired = errors.New("missing required value")
+)
+
+// ErrorList is a list of errors, ie: every problem found by p.Check(), or by parsing with CollectErrors
+type ErrorList []error
+
+// Error returns each error on its own line, prefixed by position for a *ParseError
+func (list ErrorList) Error() string {
+	msgs := make([]string, len(list))
+	for i, err := range list {
+		if parseErr, ok := err.(*ParseError); ok {
+			msgs[i] = parseErr.describe()
+		} else {
+			msgs[i] = err.Error()
+		}
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors in the list, for errors.Is and errors.As
+func (list ErrorList) Unwrap() []error {
+	return list
+}
+
+// ParseError is a problem with the token at Position in argv, returned by parsing, or in an ErrorList when CollectErrors is set
+// Position is len(argv) and Token is empty for problems found after the last token, ie: missing required values
+type ParseError struct {
+	// Position of the token in argv, after response files are expanded
+	Position int
+	// Token at Position, redacted for sensitive values
+	Token string
+	// Kind of problem, ie: ErrInvalidFlag
+	Kind error
+	// Err describes the problem
+	Err error
+	// Expects describes valid values for the token, ie: "a single integer"
+	Expects string
+
+	// action of the command matched before the problem, if any
+	action string
+	// argV which Position refers to, after response files are expanded
+	argV []string
+}
+
+// Error returns the problem, ie: `invalid flag <-x> encountered`
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+// describe returns the position and token along with the problem, ie: `position 3 <-x>: invalid flag <-x> encountered`
+func (e *ParseError) describe() string {
+	msg := "position " + strconv.Itoa(e.Position)
+	if len(e.Token) > 0 {
+		msg += " <" + e.Token + ">"
+	}
+
+	return msg + ": " + e.Error()
+}
+
+// Is returns true if target is the kind of problem, ie: errors.Is(err, ErrInvalidFlag)
+func (e *ParseError) Is(target error) bool {
+	return e.Kind == target
+}
+
+// Unwrap returns the error describing the problem
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// expects describes valid values for a flag or argument value, ie: the value of `-flag=value` or a positional
+func expects(argType ArgType, choices []string) string {
+	if len(choices) > 0 {
+		return "one of: " + strings.Join(choices, ", ")
+	}
+
+	switch argType {
+	case BOOL:
+		return "true or false"
+	case COUNT:
+		return "a non-negative integer"
+	}
+
+	return argType.Expects()
+}
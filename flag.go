@@ -1,8 +1,12 @@
 package flag
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
+	"strings"
 )
 
 // Flag represents an allowed -flag param in the structure:
@@ -19,32 +23,78 @@ type Flag struct {
 	// Details regarding flag usage
 	Help string
 
+	// Hidden flags are parsed but omitted from help
+	Hidden bool `json:"hidden,omitempty"`
+	// Deprecated flags are parsed but warn with this message, ie: "use -branch instead"
+	Deprecated string `json:"deprecated,omitempty"`
+
+	// Throws error if required and not provided, or prompts if interactive
+	Required bool `json:"required,omitempty"`
+	// Sensitive flags mask interactive input and redact values from help, errors and JSON
+	// Values may be read from a file or stdin, ie: `-token @path/to/token` or `-token -`
+	Sensitive bool `json:"sensitive,omitempty"`
+	// Choices restricts values to the given set, prompted as a menu if interactive
+	Choices []string `json:"choices,omitempty"`
+
+	// Default value displayed in help, and stored in registered targets when not provided
+	Default interface{} `json:"default,omitempty"`
+
 	// Populated values for returned flags
 	Value interface{} `json:"value,omitempty"`
+
+	// Pointer filled with the parsed value or default after parsing, for flags registered by p.String() etc
+	target interface{}
+}
+
+// redacted replaces sensitive values in output
+const redacted = "******"
+
+// MarshalJSON redacts values of sensitive flags
+func (flag Flag) MarshalJSON() ([]byte, error) {
+	type plainFlag Flag
+	plain := plainFlag(flag)
+	if flag.Sensitive {
+		if plain.Value != nil {
+			plain.Value = redacted
+		}
+		if plain.Default != nil {
+			plain.Default = redacted
+		}
+	}
+
+	return json.Marshal(plain)
 }
 
 // Parse attempts to set the given value for the given flag. Returns false if it does not meet type criteria
 func (flag *Flag) Parse(value string) error {
+	if !isChoice(value, flag.Choices) {
+		return fmt.Errorf("Invalid value encountered. Cannot set <" + flag.display(value) + "> for flag <" + flag.Name + "> - expected one of: " + strings.Join(flag.Choices, ", "))
+	}
+
 	switch flag.Type {
 	case DEFAULT:
 		// String
 		if flag.Value != nil {
-			return fmt.Errorf("Redundant value encountered. Cannot set <" + value + "> for single STRING flag <" + flag.Name + "> - already contains value: " + flag.Value.(string))
+			return fmt.Errorf("Redundant value encountered. Cannot set <" + flag.display(value) + "> for single STRING flag <" + flag.Name + "> - already contains value: " + flag.display(flag.Value))
 		}
 		flag.Value = value
 	case BOOL:
 		// Existence is sufficient
 		flag.Value = true
+	case COUNT:
+		// Each occurrence increments the count
+		count, _ := flag.Value.(int)
+		flag.Value = count + 1
 	case INT:
 		if flag.Value != nil {
-			return fmt.Errorf("Redundant value encountered. Cannot set <" + value + "> for single INT flag <" + flag.Name + "> - already contains value: " + flag.Value.(string))
+			return fmt.Errorf("Redundant value encountered. Cannot set <" + flag.display(value) + "> for single INT flag <" + flag.Name + "> - already contains value: " + flag.display(flag.Value))
 		}
 
 		if val, err := strconv.Atoi(value); err == nil {
 			// Value is number type
 			flag.Value = val
 		} else {
-			return fmt.Errorf("Invalid value encountered. Cannot set <" + value + "> for INT flag <" + flag.Name + ">")
+			return fmt.Errorf("Invalid value encountered. Cannot set <" + flag.display(value) + "> for INT flag <" + flag.Name + ">")
 		}
 	case INTS:
 		if val, err := strconv.Atoi(value); err == nil {
@@ -55,7 +105,7 @@ func (flag *Flag) Parse(value string) error {
 				flag.Value = []int{val}
 			}
 		} else {
-			return fmt.Errorf("Invalid value encountered. Cannot set <" + value + "> for INTS flag <" + flag.Name + ">")
+			return fmt.Errorf("Invalid value encountered. Cannot set <" + flag.display(value) + "> for INTS flag <" + flag.Name + ">")
 		}
 	case STRINGS:
 		if slice, ok := flag.Value.([]string); ok {
@@ -63,9 +113,164 @@ func (flag *Flag) Parse(value string) error {
 		} else {
 			flag.Value = []string{value}
 		}
+	case MAP, INTMAP, BOOLMAP:
+		return flag.parsePair(value)
+	default:
+		return fmt.Errorf("Invalid type encountered. Cannot set <" + flag.display(value) + "> for unknown type of flag <" + flag.Name + ">")
+	}
+
+	return nil
+}
+
+// parsePair adds a key=value pair to a MAP, INTMAP or BOOLMAP flag. Rejects malformed pairs and duplicate keys
+func (flag *Flag) parsePair(value string) error {
+	val, err := addPair(flag.Type, flag.Value, value)
+	switch err {
+	case nil:
+		flag.Value = val
+	case errMalformedPair:
+		return fmt.Errorf("Invalid value encountered. Cannot set <" + flag.display(value) + "> for " + string(flag.Type) + " flag <" + flag.Name + "> - expected key=value")
+	case errDuplicateKey:
+		return fmt.Errorf("Redundant value encountered. Cannot set <" + flag.display(value) + "> for " + string(flag.Type) + " flag <" + flag.Name + "> - already contains key: " + flag.display(pairKey(value)))
+	default:
+		return fmt.Errorf("Invalid value encountered. Cannot set <" + flag.display(value) + "> for " + string(flag.Type) + " flag <" + flag.Name + ">")
+	}
+
+	return nil
+}
+
+// isMap returns true for key=value flag types
+func (flag *Flag) isMap() bool {
+	switch flag.Type {
+	case MAP, INTMAP, BOOLMAP:
+		return true
+	}
+
+	return false
+}
+
+// instance returns a new flag with the same definition, for populating parsed values
+func (flag *Flag) instance() *Flag {
+	return &Flag{
+		Name:        flag.Name,
+		Identifiers: copyStrings(flag.Identifiers),
+		Type:        flag.Type,
+		Help:        flag.Help,
+		Hidden:      flag.Hidden,
+		Deprecated:  flag.Deprecated,
+		Required:    flag.Required,
+		Sensitive:   flag.Sensitive,
+		Choices:     copyStrings(flag.Choices),
+		Default:     copyValue(flag.Default),
+	}
+}
+
+// display returns a printable value, redacted if sensitive
+func (flag *Flag) display(value interface{}) string {
+	if flag.Sensitive {
+		return redacted
+	}
+
+	return fmt.Sprint(value)
+}
+
+// helpText returns flag usage details, including the default value if set
+func (flag *Flag) helpText() string {
+	if flag.Default == nil {
+		return flag.Help
+	}
+
+	return strings.TrimSpace(flag.Help + " (default: " + flag.display(flag.Default) + ")")
+}
+
+// readSensitive resolves sensitive values from a file (@path) or stdin (-)
+func (flag *Flag) readSensitive(value string, stdin io.Reader) (string, error) {
+	if !flag.Sensitive {
+		return value, nil
+	}
+
+	var data []byte
+	var err error
+	switch {
+	case value == "-":
+		data, err = io.ReadAll(stdin)
+	case strings.HasPrefix(value, "@") && len(value) > 1:
+		data, err = os.ReadFile(value[1:])
+	default:
+		return value, nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("unable to read value for sensitive flag <" + flag.Name + ">: " + err.Error())
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// isChoice returns true if value is one of choices, or choices are not restricted
+func isChoice(value string, choices []string) bool {
+	if len(choices) == 0 {
+		return true
+	}
+
+	for _, choice := range choices {
+		if choice == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// assign sets an explicitly provided value, ie: -flag=value
+// BOOL flags accept true/false, COUNT flags accept a number of occurrences
+func (flag *Flag) assign(value string) error {
+	switch flag.Type {
+	case BOOL:
+		val, err := parseBool(value)
+		if err != nil {
+			return fmt.Errorf("Invalid value encountered. Cannot set <" + flag.display(value) + "> for BOOL flag <" + flag.Name + ">")
+		}
+		flag.Value = val
+	case COUNT:
+		val, err := strconv.Atoi(value)
+		if err != nil || val < 0 {
+			return fmt.Errorf("Invalid value encountered. Cannot set <" + flag.display(value) + "> for COUNT flag <" + flag.Name + ">")
+		}
+		flag.Value = val
 	default:
-		return fmt.Errorf("Invalid type encountered. Cannot set <" + value + "> for unknown type of flag <" + flag.Name + ">")
+		return flag.Parse(value)
 	}
 
 	return nil
 }
+
+// negations returns the generated -no-<name> identifiers for BOOL flags
+func (flag *Flag) negations() (identifiers []string) {
+	if flag.Type != BOOL {
+		return
+	}
+
+	for _, identifier := range flag.Identifiers {
+		name := strings.TrimLeft(identifier, "-")
+		identifiers = append(identifiers, identifier[:len(identifier)-len(name)]+"no-"+name)
+	}
+
+	return
+}
+
+// hasIdentifier returns true if identifier matches the flag, including generated negations
+func (flag *Flag) hasIdentifier(identifier string) bool {
+	for _, id := range flag.helpIdentifiers() {
+		if id == identifier {
+			return true
+		}
+	}
+
+	return false
+}
+
+// helpIdentifiers returns identifiers to display in help, including negations
+func (flag *Flag) helpIdentifiers() []string {
+	return append(append([]string{}, flag.Identifiers...), flag.negations()...)
+}
@@ -1,5 +1,10 @@
 module github.com/hatchify/parg
 
-go 1.14
+go 1.20
 
-require github.com/hatchify/simply v0.0.18
+require (
+	github.com/hatchify/simply v0.0.18
+	golang.org/x/term v0.29.0
+)
+
+require golang.org/x/sys v0.30.0 // indirect
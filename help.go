@@ -0,0 +1,85 @@
+package flag
+
+import (
+	"fmt"
+)
+
+// defaultHelpFlags are recognized by every command unless HelpFlags are configured or DisableHelpFlags is set
+var defaultHelpFlags = []string{"-h", "-help", "--help"}
+
+// helpFlags returns identifiers which short-circuit parsing to print help
+func (p *Parg) helpFlags() []string {
+	if p.DisableHelpFlags {
+		return nil
+	}
+
+	if len(p.HelpFlags) > 0 {
+		return p.HelpFlags
+	}
+
+	return defaultHelpFlags
+}
+
+// matchHelp returns a help command if argV contains a help flag, for the first command found in argV
+// Help flags configured as regular flags are ignored, including flags scoped to the command once it's found
+func (c *Parser) matchHelp(argV []string) *Command {
+	p := c.parg
+	helpFlags := p.helpFlags()
+	allowedFlags := c.flags
+	found := false
+	for i := 1; i < len(argV); i++ {
+		if _, ok := allowedFlags[argV[i]]; ok {
+			continue
+		}
+
+		if !found {
+			if cmd, _ := p.matchCommand(argV[i], c.commands); cmd != nil {
+				// Flags scoped to the command are allowed after it
+				found = true
+				if scoped, ok := c.scoped[cmd]; ok {
+					allowedFlags = scoped
+				}
+
+				continue
+			}
+		}
+
+		for _, helpFlag := range helpFlags {
+			if argV[i] == helpFlag {
+				return p.helpCommand(argV, c.commands)
+			}
+		}
+	}
+
+	return nil
+}
+
+// helpCommand returns a help command for the first command found in argV
+// Uses the handler of a configured help command if available, otherwise prints help to Output
+func (p *Parg) helpCommand(argV []string, allowedCommands map[string]*Command) *Command {
+	command := NewCommand()
+	command.Action = "help"
+	command.handler = p.printHelp
+	if definition, ok := allowedCommands["help"]; ok {
+		command.helpDetails = definition.helpDetails
+		command.hooks = p.hooks.with(definition)
+		if definition.handler != nil {
+			command.handler = definition.handler
+		}
+	}
+
+	for i := 1; i < len(argV); i++ {
+		if cmd, _ := p.matchCommand(argV[i], allowedCommands); cmd != nil && len(cmd.Action) > 0 {
+			command.Arguments = append(command.Arguments, &Argument{Name: cmd.Action, Value: cmd.Action})
+			break
+		}
+	}
+
+	return command
+}
+
+// printHelp writes the command's help to Output
+func (p *Parg) printHelp(cmd *Command) (err error) {
+	_, err = fmt.Fprintln(p.output(), p.commandHelp(cmd, false))
+	return
+}
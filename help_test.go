@@ -0,0 +1,151 @@
+package flag
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func helpParg(out *bytes.Buffer) *Parg {
+	parg := New()
+	parg.Output = out
+	parg.AddCommand(Command{
+		Action:      syncAction,
+		Aliases:     []string{"s"},
+		Arguments:   []*Argument{{Name: "module", Required: true}},
+		helpDetails: "Sync a module",
+	})
+	parg.AddGlobalFlag(bConfigFlag)
+	return parg
+}
+
+func TestHelpFlag_MissingRequired(context *testing.T) {
+	var out bytes.Buffer
+	parg := helpParg(&out)
+
+	command, err := parg.validate(strings.Split("gomu s -x -h", " "))
+
+	test := simply.Target(err, context, "Help flag should short-circuit validation")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Action, context, "Action should be <help>")
+	result = test.Equals("help")
+	test.Validate(result)
+
+	test = simply.Target(command.Args(), context, "Help should be for the matched command")
+	result = test.Equals([]string{syncAction})
+	test.Validate(result)
+
+	command.Exec()
+
+	test = simply.Target(strings.Contains(out.String(), "Command: sync"), context, "Command help should be printed")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(out.String(), "Sync a module"), context, "Command details should be printed")
+	result = test.Equals(true)
+	test.Validate(result)
+}
+
+func TestHelpFlag_Configured(context *testing.T) {
+	var out bytes.Buffer
+	parg := helpParg(&out)
+	parg.HelpFlags = []string{"-?"}
+
+	_, err := parg.validate(strings.Split("gomu sync --help", " "))
+
+	test := simply.Target(err, context, "Default help flags should be replaced")
+	result := test.DoesNotEqual(nil)
+	test.Validate(result)
+
+	command, err := parg.validate(strings.Split("gomu sync -?", " "))
+
+	test = simply.Target(err, context, "Configured help flag should short-circuit")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Action, context, "Action should be <help>")
+	result = test.Equals("help")
+	test.Validate(result)
+
+	parg.DisableHelpFlags = true
+	_, err = parg.validate(strings.Split("gomu sync -?", " "))
+
+	test = simply.Target(err, context, "Disabled help flags should be invalid")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+}
+
+func TestHelpFlag_Handler(context *testing.T) {
+	var out bytes.Buffer
+	var helped []string
+	parg := helpParg(&out)
+	parg.AddHandler("help", func(cmd *Command) error {
+		helped = cmd.Args()
+		return nil
+	}, "Show help")
+
+	command, err := parg.validate(strings.Split("gomu sync --help", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	command.Exec()
+
+	test = simply.Target(helped, context, "Configured help handler should be used")
+	result = test.Equals([]string{syncAction})
+	test.Validate(result)
+}
+
+func TestHelpFlag_CommandFlag(context *testing.T) {
+	var out bytes.Buffer
+	parg := helpParg(&out)
+	parg.AddCommand(Command{
+		Action: "connect",
+		Flags: map[string]*Flag{
+			"-h": {Name: "-h", Identifiers: []string{"-h", "-host"}},
+		},
+	})
+
+	command, err := parg.validate(strings.Split("gomu connect -h localhost", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom("-h"), context, "Command flag should not be taken as help")
+	result = test.Equals("localhost")
+	test.Validate(result)
+
+	command, err = parg.validate(strings.Split("gomu sync -h", " "))
+
+	test = simply.Target(command.Action, context, "Help flag should apply to other commands")
+	result = test.Equals("help")
+	test.Validate(result)
+}
+
+func TestHelpFlag_OwnParg(context *testing.T) {
+	var out bytes.Buffer
+	parg := helpParg(&out)
+	New().AddAction(deployAction, "")
+
+	command, err := parg.validate(strings.Split("gomu sync -h", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	command.Exec()
+
+	test = simply.Target(strings.Contains(out.String(), "Sync a module"), context, "Help should be rendered from the parsing parg")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(out.String(), "invalid command"), context, "Help should not use the latest parg")
+	result = test.Equals(false)
+	test.Validate(result)
+}
@@ -0,0 +1,74 @@
+package flag
+
+// Handler is called by Command.Exec for a matched command
+type Handler func(cmd *Command) (err error)
+
+// PostRunHook is called after a command's handler with the handler's error
+// The returned error replaces the handler's error, return err unchanged to preserve it
+type PostRunHook func(cmd *Command, err error) error
+
+// Middleware wraps a handler, ie: for logging, timing or auth checks
+type Middleware func(next Handler) Handler
+
+// hooks holds pre-run hooks, post-run hooks and middleware for Parg or a Command
+type hooks struct {
+	preRun     []Handler
+	postRun    []PostRunHook
+	middleware []Middleware
+}
+
+// with returns a new set of hooks in execution order for cmd:
+// Parg pre-run, Command pre-run, Parg middleware, Command middleware, handler, Command post-run, Parg post-run
+func (h hooks) with(cmd *Command) (merged hooks) {
+	merged.preRun = append(merged.preRun, h.preRun...)
+	merged.middleware = append(merged.middleware, h.middleware...)
+	if cmd != nil {
+		merged.preRun = append(merged.preRun, cmd.hooks.preRun...)
+		merged.middleware = append(merged.middleware, cmd.hooks.middleware...)
+		merged.postRun = append(merged.postRun, cmd.hooks.postRun...)
+	}
+	merged.postRun = append(merged.postRun, h.postRun...)
+
+	return
+}
+
+// wrap returns handler wrapped by middleware, the first middleware being outermost
+func (h hooks) wrap(handler Handler) Handler {
+	for i := len(h.middleware) - 1; i >= 0; i-- {
+		handler = h.middleware[i](handler)
+	}
+
+	return handler
+}
+
+// AddPreRun appends a hook called before every command's handler
+// An error returned by a pre-run hook stops execution
+func (p *Parg) AddPreRun(hook Handler) {
+	p.hooks.preRun = append(p.hooks.preRun, hook)
+}
+
+// AddPostRun appends a hook called after every command's handler
+func (p *Parg) AddPostRun(hook PostRunHook) {
+	p.hooks.postRun = append(p.hooks.postRun, hook)
+}
+
+// Use appends middleware wrapping every command's handler
+func (p *Parg) Use(middleware Middleware) {
+	p.hooks.middleware = append(p.hooks.middleware, middleware)
+}
+
+// AddPreRun appends a hook called before this command's handler, after Parg pre-run hooks
+// An error returned by a pre-run hook stops execution
+func (cmd *Command) AddPreRun(hook Handler) {
+	cmd.hooks.preRun = append(cmd.hooks.preRun, hook)
+}
+
+// AddPostRun appends a hook called after this command's handler, before Parg post-run hooks
+func (cmd *Command) AddPostRun(hook PostRunHook) {
+	cmd.hooks.postRun = append(cmd.hooks.postRun, hook)
+}
+
+// Use appends middleware wrapping this command's handler, inside Parg middleware
+func (cmd *Command) Use(middleware Middleware) {
+	cmd.hooks.middleware = append(cmd.hooks.middleware, middleware)
+}
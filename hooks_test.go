@@ -0,0 +1,113 @@
+package flag
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestExec_HookOrder(context *testing.T) {
+	var calls []string
+	record := func(name string) Handler {
+		return func(cmd *Command) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+	wrap := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(cmd *Command) error {
+				calls = append(calls, name+" before")
+				err := next(cmd)
+				calls = append(calls, name+" after")
+				return err
+			}
+		}
+	}
+
+	handlerErr := errors.New("handler failed")
+	sync := Command{Action: syncAction, handler: func(cmd *Command) error {
+		calls = append(calls, "handler")
+		return handlerErr
+	}}
+	sync.AddPreRun(record("command pre"))
+	sync.Use(wrap("command middleware"))
+	sync.AddPostRun(func(cmd *Command, err error) error {
+		calls = append(calls, "command post: "+err.Error())
+		return err
+	})
+
+	parg := New()
+	parg.AddCommand(sync)
+	parg.AddPreRun(record("parg pre"))
+	parg.Use(wrap("parg middleware"))
+	parg.AddPostRun(func(cmd *Command, err error) error {
+		calls = append(calls, "parg post: "+err.Error())
+		return nil
+	})
+
+	command, err := parg.validate(strings.Split("gomu sync", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	err = command.Exec()
+
+	test = simply.Target(err, context, "Parg post-run hook should replace handler error")
+	result = test.Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(calls, context, "Hooks should run in order")
+	result = test.Equals([]string{
+		"parg pre",
+		"command pre",
+		"parg middleware before",
+		"command middleware before",
+		"handler",
+		"command middleware after",
+		"parg middleware after",
+		"command post: handler failed",
+		"parg post: handler failed",
+	})
+	test.Validate(result)
+}
+
+func TestExec_PreRunError(context *testing.T) {
+	var handled bool
+	preRunErr := errors.New("unauthorized")
+
+	parg := New()
+	parg.AddHandler(syncAction, func(cmd *Command) error {
+		handled = true
+		return nil
+	}, "")
+	parg.AddPreRun(func(cmd *Command) error {
+		return preRunErr
+	})
+
+	command, err := parg.validate(strings.Split("gomu sync", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Exec(), context, "Exec should return pre-run error")
+	result = test.Equals(preRunErr)
+	test.Validate(result)
+
+	test = simply.Target(handled, context, "Handler should not run")
+	result = test.Equals(false)
+	test.Validate(result)
+}
+
+func TestExec_NoHandler(context *testing.T) {
+	command := NewCommand()
+	command.Action = syncAction
+
+	test := simply.Target(command.Exec(), context, "Exec should error without handler")
+	result := test.DoesNotEqual(nil)
+	test.Validate(result)
+}
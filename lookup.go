@@ -0,0 +1,223 @@
+package flag
+
+import "fmt"
+
+// Lookup returns the parsed flag matching identifier, which may be the flag's name or any of its identifiers
+// Returns false if the flag was not provided
+func (cmd *Command) Lookup(identifier string) (*Flag, bool) {
+	if flag, ok := cmd.Flags[identifier]; ok {
+		return flag, true
+	}
+
+	for _, flag := range cmd.Flags {
+		if flag.hasIdentifier(identifier) {
+			return flag, true
+		}
+	}
+
+	return nil, false
+}
+
+// Changed returns true if the flag matching identifier was provided
+func (cmd *Command) Changed(identifier string) bool {
+	_, ok := cmd.Lookup(identifier)
+	return ok
+}
+
+// lookupValue returns the value of the flag matching identifier, and the flag's name for errors
+func (cmd *Command) lookupValue(identifier string) (value interface{}, name string, ok bool) {
+	flag, ok := cmd.Lookup(identifier)
+	if !ok {
+		return nil, identifier, false
+	}
+
+	return flag.Value, flag.Name, true
+}
+
+// flagTypeError returns an error for a flag value which isn't of the expected type
+func flagTypeError(name string, value interface{}, expected string) error {
+	return fmt.Errorf("flag <%s> is %T, not %s", name, value, expected)
+}
+
+// LookupString returns the string value of the flag matching identifier
+// ok is false if the flag was not provided, err is set if the value is not a string
+func (cmd *Command) LookupString(identifier string) (val string, ok bool, err error) {
+	value, name, ok := cmd.lookupValue(identifier)
+	if !ok {
+		return
+	}
+
+	if val, ok = value.(string); !ok {
+		return val, true, flagTypeError(name, value, "string")
+	}
+	return
+}
+
+// LookupStrings returns the []string value of the flag matching identifier
+// ok is false if the flag was not provided, err is set if the value is not a []string
+func (cmd *Command) LookupStrings(identifier string) (vals []string, ok bool, err error) {
+	value, name, ok := cmd.lookupValue(identifier)
+	if !ok {
+		return
+	}
+
+	if vals, ok = value.([]string); !ok {
+		return vals, true, flagTypeError(name, value, "[]string")
+	}
+	return
+}
+
+// LookupInt returns the int value of the flag matching identifier, including COUNT flags
+// ok is false if the flag was not provided, err is set if the value is not an int
+func (cmd *Command) LookupInt(identifier string) (val int, ok bool, err error) {
+	value, name, ok := cmd.lookupValue(identifier)
+	if !ok {
+		return
+	}
+
+	if val, ok = value.(int); !ok {
+		return val, true, flagTypeError(name, value, "int")
+	}
+	return
+}
+
+// LookupInts returns the []int value of the flag matching identifier
+// ok is false if the flag was not provided, err is set if the value is not an []int
+func (cmd *Command) LookupInts(identifier string) (vals []int, ok bool, err error) {
+	value, name, ok := cmd.lookupValue(identifier)
+	if !ok {
+		return
+	}
+
+	if vals, ok = value.([]int); !ok {
+		return vals, true, flagTypeError(name, value, "[]int")
+	}
+	return
+}
+
+// LookupBool returns the bool value of the flag matching identifier
+// ok is false if the flag was not provided, err is set if the value is not a bool
+func (cmd *Command) LookupBool(identifier string) (val bool, ok bool, err error) {
+	value, name, ok := cmd.lookupValue(identifier)
+	if !ok {
+		return
+	}
+
+	if val, ok = value.(bool); !ok {
+		return val, true, flagTypeError(name, value, "bool")
+	}
+	return
+}
+
+// LookupMap returns the map[string]string value of the flag matching identifier
+// ok is false if the flag was not provided, err is set if the value is not a map[string]string
+func (cmd *Command) LookupMap(identifier string) (vals map[string]string, ok bool, err error) {
+	value, name, ok := cmd.lookupValue(identifier)
+	if !ok {
+		return
+	}
+
+	if vals, ok = value.(map[string]string); !ok {
+		return vals, true, flagTypeError(name, value, "map[string]string")
+	}
+	return
+}
+
+// LookupIntMap returns the map[string]int value of the flag matching identifier
+// ok is false if the flag was not provided, err is set if the value is not a map[string]int
+func (cmd *Command) LookupIntMap(identifier string) (vals map[string]int, ok bool, err error) {
+	value, name, ok := cmd.lookupValue(identifier)
+	if !ok {
+		return
+	}
+
+	if vals, ok = value.(map[string]int); !ok {
+		return vals, true, flagTypeError(name, value, "map[string]int")
+	}
+	return
+}
+
+// LookupBoolMap returns the map[string]bool value of the flag matching identifier
+// ok is false if the flag was not provided, err is set if the value is not a map[string]bool
+func (cmd *Command) LookupBoolMap(identifier string) (vals map[string]bool, ok bool, err error) {
+	value, name, ok := cmd.lookupValue(identifier)
+	if !ok {
+		return
+	}
+
+	if vals, ok = value.(map[string]bool); !ok {
+		return vals, true, flagTypeError(name, value, "map[string]bool")
+	}
+	return
+}
+
+// mustLookup panics with err, for flags accessed as the wrong type
+func mustLookup(err error) {
+	if err != nil {
+		panic("parg: " + err.Error())
+	}
+}
+
+// MustString returns the string value of the flag matching identifier, or "" if not provided
+// Panics if the flag's value is not a string
+func (cmd *Command) MustString(identifier string) string {
+	val, _, err := cmd.LookupString(identifier)
+	mustLookup(err)
+	return val
+}
+
+// MustStrings returns the []string value of the flag matching identifier, or nil if not provided
+// Panics if the flag's value is not a []string
+func (cmd *Command) MustStrings(identifier string) []string {
+	vals, _, err := cmd.LookupStrings(identifier)
+	mustLookup(err)
+	return vals
+}
+
+// MustInt returns the int value of the flag matching identifier, or 0 if not provided
+// Panics if the flag's value is not an int
+func (cmd *Command) MustInt(identifier string) int {
+	val, _, err := cmd.LookupInt(identifier)
+	mustLookup(err)
+	return val
+}
+
+// MustInts returns the []int value of the flag matching identifier, or nil if not provided
+// Panics if the flag's value is not an []int
+func (cmd *Command) MustInts(identifier string) []int {
+	vals, _, err := cmd.LookupInts(identifier)
+	mustLookup(err)
+	return vals
+}
+
+// MustBool returns the bool value of the flag matching identifier, or false if not provided
+// Panics if the flag's value is not a bool
+func (cmd *Command) MustBool(identifier string) bool {
+	val, _, err := cmd.LookupBool(identifier)
+	mustLookup(err)
+	return val
+}
+
+// MustMap returns the map[string]string value of the flag matching identifier, or nil if not provided
+// Panics if the flag's value is not a map[string]string
+func (cmd *Command) MustMap(identifier string) map[string]string {
+	vals, _, err := cmd.LookupMap(identifier)
+	mustLookup(err)
+	return vals
+}
+
+// MustIntMap returns the map[string]int value of the flag matching identifier, or nil if not provided
+// Panics if the flag's value is not a map[string]int
+func (cmd *Command) MustIntMap(identifier string) map[string]int {
+	vals, _, err := cmd.LookupIntMap(identifier)
+	mustLookup(err)
+	return vals
+}
+
+// MustBoolMap returns the map[string]bool value of the flag matching identifier, or nil if not provided
+// Panics if the flag's value is not a map[string]bool
+func (cmd *Command) MustBoolMap(identifier string) map[string]bool {
+	vals, _, err := cmd.LookupBoolMap(identifier)
+	mustLookup(err)
+	return vals
+}
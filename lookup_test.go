@@ -0,0 +1,75 @@
+package flag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestCommand_Lookup(context *testing.T) {
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(branchConfigFlag)
+	parg.AddGlobalFlag(vConfigFlag)
+	parg.AddGlobalFlag(includeConfigFlag)
+
+	command, err := parg.validate(strings.Split("gomu sync -branch main -verbose", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	branch, ok, err := command.LookupString("-branch")
+
+	test = simply.Target(branch, context, "Branch should be found by any identifier")
+	result = test.Equals("main")
+	test.Validate(result)
+
+	test = simply.Target(ok && err == nil, context, "Branch should be present without error")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	verbosity, ok, err := command.LookupInt("-v")
+
+	test = simply.Target(verbosity == 1 && ok && err == nil, context, "Count should be found as int")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	_, ok, err = command.LookupStrings(bFlagName)
+
+	test = simply.Target(ok, context, "Mistyped flag should still be present")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(err.Error(), context, "Mistyped flag should report its actual type")
+	result = test.Equals("flag <-b> is string, not []string")
+	test.Validate(result)
+
+	_, ok, err = command.LookupStrings("-include")
+
+	test = simply.Target(ok || err != nil, context, "Absent flag should not be present or error")
+	result = test.Equals(false)
+	test.Validate(result)
+
+	test = simply.Target([]bool{command.Changed("-branch"), command.Changed("-i")}, context, "Changed should report presence")
+	result = test.Equals([]bool{true, false})
+	test.Validate(result)
+}
+
+func TestCommand_Must(context *testing.T) {
+	command := NewCommand()
+	command.Flags[bFlagName] = &Flag{Name: bFlagName, Identifiers: []string{bFlagName}, Value: "main"}
+
+	test := simply.Target(command.MustString(bFlagName)+command.MustString("-branch"), context, "Must should return values and zero values")
+	result := test.Equals("main")
+	test.Validate(result)
+
+	defer func() {
+		test := simply.Target(recover(), context, "Must should panic for mistyped flags")
+		result := test.Equals("parg: flag <-b> is string, not int")
+		test.Validate(result)
+	}()
+
+	command.MustInt(bFlagName)
+}
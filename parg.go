@@ -3,29 +3,91 @@ package flag
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // Parg represents expected argument config.
 // Add commands and flags to Parg, then call p.Arguments() to get the command or error
 // Optionally call static parg.Parse() to get a generically parsed command
+// Once configured, a Parg may parse from many goroutines simultaneously, and returned commands share nothing with its definitions
+// Configuring, Shell and interactive prompts are not safe for concurrent use, and registered flag targets hold values from the latest parse
 type Parg struct {
 	// AllowedCommands indicates a command config to validate
 	// Empty list implies no commands arguments are allowed
 	AllowedCommands []Command
 	// GlobalFlags apply to all commands
 	GlobalFlags []Flag
+
+	// PrefixMatching allows commands to be abbreviated by any unique prefix, ie: `st` for `status`
+	PrefixMatching bool
+
+	// Warnings receives deprecation notices for matched commands and flags. Defaults to os.Stderr
+	Warnings io.Writer
+
+	// ResponseFiles expands @path arguments with the arguments contained in the file at path
+	ResponseFiles bool
+
+	// Interactive prompts for missing required arguments and flags when Stdin is a terminal
+	Interactive bool
+	// Stdin is read by interactive prompts. Defaults to os.Stdin
+	Stdin io.Reader
+	// Prompts receives interactive prompts. Defaults to os.Stderr
+	Prompts io.Writer
+
+	// Output receives help and version output. Defaults to os.Stdout
+	Output io.Writer
+
+	// HelpFlags short-circuit parsing to print help for the matched command. Defaults to -h, -help and --help
+	HelpFlags []string
+	// DisableHelpFlags stops help flags from being recognized
+	DisableHelpFlags bool
+
+	// CollectErrors continues parsing past unknown flags, bad values and excess arguments
+	// Every problem is returned as a *ParseError in an ErrorList
+	// Flag values are stricter than when stopping at the first problem: a missing or invalid value is reported,
+	// rather than the flag being left unset and the token parsed as an argument
+	CollectErrors bool
+
+	// Hooks and middleware run by Exec for every command
+	hooks hooks
+
+	// version printed by the version command and flag, if registered by AddVersion
+	version   string
+	versioned bool
+
+	// terminal overrides terminal detection for Stdin
+	terminal func(r io.Reader) bool
+
+	// mutex guards registered flag targets, as parsing may be concurrent
+	mutex sync.Mutex
 }
 
-var staticParg *Parg
+var (
+	// staticParg is the most recent Parg returned by New, used by Validate() and help
+	staticParg *Parg
+	// staticMutex guards staticParg, which may be replaced while other goroutines read it
+	staticMutex sync.RWMutex
+)
+
+// getStaticParg returns the most recent Parg returned by New, or nil
+func getStaticParg() *Parg {
+	staticMutex.RLock()
+	defer staticMutex.RUnlock()
+	return staticParg
+}
 
 // New returns a clean instance of Parg
 func New() *Parg {
 	var parg Parg
 	parg.AllowedCommands = []Command{}
 	parg.GlobalFlags = []Flag{}
+	staticMutex.Lock()
 	staticParg = &parg
+	staticMutex.Unlock()
 	return &parg
 }
 
@@ -44,7 +106,7 @@ func (p *Parg) AddAction(action string, usage string) {
 	var command Command
 	command.Action = action
 	command.helpDetails = usage
-	p.AllowedCommands = append(p.AllowedCommands, command)
+	p.AddCommand(command)
 }
 
 // AddHandler is a shortcut for adding a command with callback on Exec
@@ -53,7 +115,7 @@ func (p *Parg) AddHandler(action string, handler func(cmd *Command) (err error),
 	command.Action = action
 	command.helpDetails = usage
 	command.handler = handler
-	p.AllowedCommands = append(p.AllowedCommands, command)
+	p.AddCommand(command)
 }
 
 // AddCommand appends an allowed command to expect.
@@ -89,6 +151,9 @@ func (p *Parg) GetAllowedCommands() (allowedCommands map[string]*Command) {
 	for i := range p.AllowedCommands {
 		command = &p.AllowedCommands[i]
 		allowedCommands[command.Action] = command
+		for _, alias := range command.Aliases {
+			allowedCommands[alias] = command
+		}
 	}
 
 	return
@@ -99,11 +164,12 @@ func (p *Parg) GetAllowedCommands() (allowedCommands map[string]*Command) {
 func Validate() (*Command, error) {
 	var argV = os.Args
 
-	if staticParg == nil {
-		staticParg = New()
+	p := getStaticParg()
+	if p == nil {
+		p = New()
 	}
 
-	return staticParg.validate(argV)
+	return p.validate(argV)
 }
 
 // Simple will return a command for the os.Args provided with default parse configuration
@@ -113,86 +179,199 @@ func Simple() *Command {
 	return simpleParse(argV)
 }
 
+// SimpleExpanded will return a command for the os.Args provided with default parse configuration,
+// after expanding @path response files
+func SimpleExpanded() (*Command, error) {
+	argV, err := ExpandResponseFiles(os.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	return simpleParse(argV), nil
+}
+
+// ParseLine returns the command for a single command line, split using shell-style quoting
+// The program name is not expected, ie: `sync "mod common" -b JIRA-Ticket`
+func (p *Parg) ParseLine(line string) (*Command, error) {
+	args, err := Tokenize(line)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.validate(append([]string{os.Args[0]}, args...))
+}
+
 // validate `p.Arguments()` returns parsed command or error if does not match configured values
+// Registered flag targets are filled on success
 func (p *Parg) validate(argV []string) (*Command, error) {
+	// Definitions may be changed directly between parses, so they're checked and looked up every time
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+
+	return newParser(p).parse(argV, true)
+}
+
+// parse returns parsed command or error if argV does not match the compiled definitions
+// Registered flag targets are filled on success if store is set
+func (c *Parser) parse(argV []string, store bool) (*Command, error) {
+	p := c.parg
 	var curCommand *Command
 	var action string
-	var handler func(cmd *Command) (err error) = nil
+	var handler Handler = nil
+	var cmdHooks = p.hooks.with(nil)
 	var args = []*Argument{}
 	var flags = map[string]*Flag{}
 	var help = ""
 
-	allowedFlags := p.GetGlobalFlags()
-	allowedCommands := p.GetAllowedCommands()
+	// fail returns a *ParseError for the token at position
+	// In CollectErrors mode, the problem is recorded instead and nil is returned, so parsing continues with the next token
+	var errs ErrorList
+	fail := func(position int, token string, kind error, err error, expects string) error {
+		parseErr := &ParseError{Position: position, Token: token, Kind: kind, Err: err, Expects: expects, action: action, argV: argV}
+		if !p.CollectErrors {
+			return parseErr
+		}
+
+		errs = append(errs, parseErr)
+		return nil
+	}
+	unknownCommand := false
+
+	if p.ResponseFiles {
+		var err error
+		if argV, err = expandResponseFiles(argV, c.sensitive); err != nil {
+			return nil, err
+		}
+	}
+
+	allowedFlags := c.flags
+	allowedCommands := c.commands
+
+	if cmd := c.matchHelp(argV); cmd != nil {
+		// Print help regardless of remaining args
+		return cmd, nil
+	}
 
 	if cmd, ok := allowedCommands[""]; ok {
 		help = cmd.helpDetails
 		handler = cmd.handler
+		cmdHooks = p.hooks.with(cmd)
+	} else if len(c.help) > 0 {
+		help = c.help
 	} else {
-		help = Help(true)
+		help = p.help(true)
 	}
 
 	var curFlag *Flag
 	var arg *string
 
+	// Position of the flag awaiting its value, 0 when there is none
+	var expecting int
+	// missingValue records the awaited flag's missing value in CollectErrors mode, ie: followed by another flag
+	missingValue := func() {
+		if expecting > 0 && p.CollectErrors {
+			err := fmt.Errorf("missing value for flag <" + curFlag.Name + ">")
+			fail(expecting, argV[expecting], ErrInvalidValue, err, expects(curFlag.Type, curFlag.Choices))
+		}
+
+		expecting = 0
+	}
+
 	for i := 1; i < len(argV); i++ {
 		arg = &argV[i]
 
-		if strings.HasPrefix(*arg, "-") {
+		if strings.HasPrefix(*arg, "-") && !(*arg == "-" && curFlag != nil && curFlag.Sensitive) {
+			missingValue()
+
+			// Split explicit values, ie: -flag=value
+			identifier, value, hasValue := splitFlag(*arg)
+
 			// Check if allows flag
-			var newFlag *Flag
-			for _, allowedFlag := range allowedFlags {
-				for index := range allowedFlag.Identifiers {
-					if allowedFlag.Identifiers[index] == *arg {
-						// We have a winner!
-						// newFlag is old, use old flag
-						// Create new flag instance
-						var ok bool
-						if newFlag, ok = flags[allowedFlag.Name]; !ok {
-							newFlag = &Flag{
-								Name:        allowedFlag.Name,
-								Identifiers: allowedFlag.Identifiers,
-								Type:        allowedFlag.Type,
-								Help:        allowedFlag.Help,
-							}
-						}
-						break
-					}
+			newFlag := matchFlag(identifier, allowedFlags, flags)
+			if newFlag == nil && !hasValue {
+				// Check for negated bool flags (-no-name-only)
+				if negated := matchNegation(identifier, allowedFlags, flags); negated != nil {
+					p.addFlag(negated, flags)
+					negated.Value = false
+					curFlag = nil
+					continue
 				}
 
-				if newFlag != nil {
-					// We already got one!
-					break
+				// Check for clustered short flags (-vvv, -vn)
+				if cluster := matchCluster(identifier, allowedFlags, flags); cluster != nil {
+					for _, clusterFlag := range cluster {
+						p.addFlag(clusterFlag, flags)
+						clusterFlag.Parse(*arg)
+					}
+
+					curFlag = nil
+					continue
 				}
 			}
 
 			if newFlag == nil {
 				// Miss job
-				return nil, fmt.Errorf("invalid flag <" + *arg + "> encountered")
+				err := fmt.Errorf("invalid flag <" + *arg + "> encountered")
+				if err = fail(i, *arg, ErrInvalidFlag, err, ""); err != nil {
+					return nil, err
+				}
+
+				curFlag = nil
+				continue
 			}
 
 			// Add the flag
-			flags[newFlag.Name] = newFlag
+			p.addFlag(newFlag, flags)
 
-			if newFlag.Type == BOOL {
-				// Existence is sufficient, no trailing args expected
-				newFlag.Value = true
+			if hasValue {
+				// Explicit value provided, no trailing args expected
 				curFlag = nil
+				token := identifier + "=" + newFlag.display(value)
+				value, err := newFlag.readSensitive(value, p.stdin())
+				if err == nil {
+					err = newFlag.assign(value)
+				}
+
+				if err != nil {
+					if err = fail(i, token, ErrInvalidValue, err, expects(newFlag.Type, newFlag.Choices)); err != nil {
+						return nil, err
+					}
+
+					continue
+				}
 			} else {
-				// Set flag and append trailing values
-				curFlag = newFlag
+				switch newFlag.Type {
+				case BOOL, COUNT:
+					// Existence is sufficient, no trailing args expected
+					newFlag.Parse(*arg)
+					curFlag = nil
+				default:
+					// Set flag and append trailing values
+					curFlag = newFlag
+					expecting = i
+				}
+			}
+
+			if p.isVersionFlag(newFlag) {
+				// Print version regardless of remaining args
+				return p.versionCommand(), nil
 			}
 		} else {
+			// The first trailing value is the flag's own, rather than a possible argument
+			ownValue := expecting > 0
+			expecting = 0
+
 			if curFlag != nil {
 				// Flag set, but check if this is an action
 				shouldParse := true
 				if len(action) == 0 {
 					// Parse action (or lack thereof)
-					if _, ok := allowedCommands[*arg]; ok {
+					if p.isCommand(*arg, allowedCommands) {
 						// This is an allowed action, check for other candidates
 						foundAction := false
 						for x := i + 1; x < len(argV); x++ {
-							if _, ok := allowedCommands[argV[x]]; ok {
+							if p.isCommand(argV[x], allowedCommands) {
 								// We have another candidate, we're probably ok to treat this as a param
 								foundAction = true
 								break
@@ -209,9 +388,31 @@ func (p *Parg) validate(argV []string) (*Command, error) {
 				if !shouldParse {
 					// This is probably a command, let's skip parsing this arg
 					curFlag = nil
-				} else if err := curFlag.Parse(*arg); err == nil {
+				} else if value, err := curFlag.readSensitive(*arg, p.stdin()); err != nil {
+					if err = fail(i, curFlag.display(*arg), ErrInvalidValue, err, expects(curFlag.Type, curFlag.Choices)); err != nil {
+						return nil, err
+					}
+
+					curFlag = nil
+					continue
+				} else if err := curFlag.Parse(value); err == nil {
 					// We parsed this arg!
 					continue
+				} else if curFlag.isMap() && (curFlag.Value == nil || strings.Contains(*arg, "=")) {
+					// Malformed or duplicate pairs can't be trailing args
+					if err = fail(i, curFlag.display(*arg), ErrInvalidValue, err, expects(curFlag.Type, curFlag.Choices)); err != nil {
+						return nil, err
+					}
+
+					continue
+				} else if ownValue && (curFlag.Sensitive || p.CollectErrors) {
+					// Sensitive values can't be reused as arguments, and are redacted in the error
+					if err = fail(i, curFlag.display(*arg), ErrInvalidValue, err, expects(curFlag.Type, curFlag.Choices)); err != nil {
+						return nil, err
+					}
+
+					curFlag = nil
+					continue
 				} else {
 					// We can't parse this arg... fall through
 					curFlag = nil
@@ -219,39 +420,78 @@ func (p *Parg) validate(argV []string) (*Command, error) {
 			}
 
 			// No flag set, this is an action or an arg
-			if len(action) == 0 {
+			if unknownCommand {
+				// Arguments can't be checked without a command
+				continue
+			} else if len(action) == 0 {
 				// Parse action (or lack thereof)
-				if cmd, ok := allowedCommands[*arg]; ok || len(*arg) == 0 && len(allowedCommands) == 0 {
+				cmd, err := p.matchCommand(*arg, allowedCommands)
+				if err != nil {
+					if err = fail(i, *arg, ErrInvalidCommand, err, ""); err != nil {
+						return nil, err
+					}
+
+					unknownCommand = true
+					continue
+				}
+
+				if cmd != nil || len(*arg) == 0 && len(allowedCommands) == 0 {
 					// Set command
 					curCommand = cmd
 					action = cmd.Action
 					handler = cmd.handler
 					help = cmd.helpDetails
+					cmdHooks = p.hooks.with(cmd)
+
+					// Flags scoped to the command are allowed after it
+					if scoped, ok := c.scoped[cmd]; ok {
+						allowedFlags = scoped
+					}
+
+					if len(cmd.Deprecated) > 0 {
+						p.warn("command <" + cmd.Action + "> is deprecated: " + cmd.Deprecated)
+					}
 				} else {
-					return nil, fmt.Errorf("invalid command <" + *arg + "> encountered")
+					err := fmt.Errorf("invalid command <" + *arg + "> encountered")
+					if err = fail(i, *arg, ErrInvalidCommand, err, ""); err != nil {
+						return nil, err
+					}
+
+					unknownCommand = true
 				}
 
 			} else {
 				// Argument?
 				var argument *Argument
 				argCount := len(args)
-				if curCommand.Arguments != nil {
-					if argCount >= len(curCommand.Arguments) {
-						// We've exceeded our argument limit
-						return nil, fmt.Errorf("invalid argument count: no rules for argument <" + *arg + ">")
+				if curCommand.Arguments == nil {
+					argument = &Argument{Name: *arg, Type: DEFAULT}
+				} else if argCount < len(curCommand.Arguments) {
+					// Parse into an instance, leaving the definition untouched
+					argument = curCommand.Arguments[argCount].instance()
+				} else if argCount > 0 && args[argCount-1].Type.isVariadic() {
+					// Final variadic argument collects the remaining values
+					if err := args[argCount-1].Parse(*arg); err != nil {
+						if err = fail(i, *arg, ErrInvalidValue, err, expects(args[argCount-1].Type, args[argCount-1].Choices)); err != nil {
+							return nil, err
+						}
 					}
 
-					argument = curCommand.Arguments[len(args)]
+					continue
 				} else {
-					if argCount > 0 && args[argCount-1].Type == STRINGS {
-						argument = args[argCount-1]
-					} else {
-						argument = &Argument{Name: *arg, Type: DEFAULT}
+					// We've exceeded our argument limit
+					err := fmt.Errorf("invalid argument count: no rules for argument <" + *arg + ">" + p.usage(curCommand))
+					if err = fail(i, *arg, ErrArgumentCount, err, ""); err != nil {
+						return nil, err
 					}
+
+					continue
 				}
 
 				if err := argument.Parse(*arg); err != nil {
-					return nil, err
+					if err = fail(i, *arg, ErrInvalidValue, err, expects(argument.Type, argument.Choices)); err != nil {
+						return nil, err
+					}
 				}
 
 				args = append(args, argument)
@@ -259,12 +499,216 @@ func (p *Parg) validate(argV []string) (*Command, error) {
 		}
 	}
 
-	if _, ok := allowedCommands[action]; ok || len(action) == 0 && len(allowedCommands) == 0 {
+	missingValue()
+
+	if unknownCommand {
+		// Already recorded
+		return nil, errs
+	} else if _, ok := allowedCommands[action]; ok || len(action) == 0 && len(allowedCommands) == 0 {
 		// Command allowed
-	} else {
-		return nil, fmt.Errorf("invalid command <" + action + "> encountered")
+	} else if err := fail(len(argV), "", ErrInvalidCommand, fmt.Errorf("invalid command <"+action+"> encountered"), ""); err != nil {
+		return nil, err
+	}
+
+	// Ensure required values were provided, prompting only if there are no other problems
+	args, err := p.require(curCommand, args, flags, len(errs) == 0)
+	if list, ok := err.(ErrorList); ok && p.CollectErrors {
+		for _, missing := range list {
+			fail(len(argV), "", ErrMissingRequired, missing, "")
+		}
+	} else if err != nil {
+		if err = fail(len(argV), "", ErrMissingRequired, err, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if store {
+		// Fill registered flag targets
+		p.mutex.Lock()
+		p.store(curCommand, flags)
+		p.mutex.Unlock()
+	}
+
+	return &Command{Action: action, Arguments: args, Flags: flags, handler: handler, helpDetails: help, hooks: cmdHooks}, nil
+}
+
+// addFlag adds a flag instance to parsed flags, warning the first time a deprecated flag is encountered
+func (p *Parg) addFlag(flag *Flag, flags map[string]*Flag) {
+	if _, ok := flags[flag.Name]; !ok && len(flag.Deprecated) > 0 {
+		p.warn("flag <" + flag.Name + "> is deprecated: " + flag.Deprecated)
+	}
+
+	flags[flag.Name] = flag
+}
+
+// withCommandFlags returns allowedFlags along with the flags scoped to cmd, by identifier
+func withCommandFlags(cmd *Command, allowedFlags map[string]*Flag) map[string]*Flag {
+	if len(cmd.Flags) == 0 {
+		return allowedFlags
+	}
+
+	merged := map[string]*Flag{}
+	for identifier, flag := range allowedFlags {
+		merged[identifier] = flag
+	}
+
+	for _, flag := range cmd.Flags {
+		for _, identifier := range flag.Identifiers {
+			merged[identifier] = flag
+		}
+	}
+
+	return merged
+}
+
+// output returns the configured writer for help and version output
+func (p *Parg) output() io.Writer {
+	if p.Output != nil {
+		return p.Output
+	}
+
+	return os.Stdout
+}
+
+// warn writes a warning to the configured writer
+func (p *Parg) warn(msg string) {
+	var w io.Writer = os.Stderr
+	if p.Warnings != nil {
+		w = p.Warnings
+	}
+
+	fmt.Fprintln(w, "Warning: "+msg)
+}
+
+// matchCommand returns the allowed command for an action or alias
+// When PrefixMatching is enabled, a unique prefix of an action or alias also matches
+// Returns an error naming the candidates if the prefix is ambiguous
+func (p *Parg) matchCommand(token string, allowedCommands map[string]*Command) (*Command, error) {
+	if cmd, ok := allowedCommands[token]; ok {
+		return cmd, nil
+	}
+
+	if !p.PrefixMatching || len(token) == 0 {
+		return nil, nil
 	}
-	return &Command{action, args, flags, handler, help}, nil
+
+	var match *Command
+	candidates := []string{}
+	for name, cmd := range allowedCommands {
+		if !strings.HasPrefix(name, token) {
+			continue
+		}
+
+		if match == nil || match.Action != cmd.Action {
+			candidates = append(candidates, cmd.Action)
+		}
+		match = cmd
+	}
+
+	if len(candidates) > 1 {
+		candidates = unique(candidates)
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+		return match, nil
+	default:
+		return nil, fmt.Errorf("ambiguous command <" + token + "> encountered, could be: " + strings.Join(candidates, ", "))
+	}
+}
+
+// isCommand returns true if token matches exactly one allowed command
+func (p *Parg) isCommand(token string, allowedCommands map[string]*Command) bool {
+	cmd, _ := p.matchCommand(token, allowedCommands)
+	return cmd != nil
+}
+
+// unique returns sorted values without duplicates
+func unique(values []string) (uniques []string) {
+	sort.Strings(values)
+	for i, value := range values {
+		if i == 0 || values[i-1] != value {
+			uniques = append(uniques, value)
+		}
+	}
+
+	return
+}
+
+// matchFlag returns the flag instance for identifier, creating it from the allowed flags if not yet parsed
+// Returns nil if identifier is not allowed
+func matchFlag(identifier string, allowedFlags map[string]*Flag, flags map[string]*Flag) *Flag {
+	allowedFlag, ok := allowedFlags[identifier]
+	if !ok {
+		return nil
+	}
+
+	if flag, ok := flags[allowedFlag.Name]; ok {
+		// Flag is old, use old flag
+		return flag
+	}
+
+	// Create new flag instance
+	return allowedFlag.instance()
+}
+
+// matchNegation returns the BOOL flag instance negated by identifier, ie: -no-name-only or --no-cache
+// Returns nil if identifier is not a negation of an allowed BOOL flag
+func matchNegation(identifier string, allowedFlags map[string]*Flag, flags map[string]*Flag) *Flag {
+	name := strings.TrimLeft(identifier, "-")
+	if !strings.HasPrefix(name, "no-") {
+		return nil
+	}
+
+	dashes := identifier[:len(identifier)-len(name)]
+	flag := matchFlag(dashes+strings.TrimPrefix(name, "no-"), allowedFlags, flags)
+	if flag == nil || flag.Type != BOOL {
+		return nil
+	}
+
+	return flag
+}
+
+// splitFlag separates an explicit value from a flag arg, ie: -flag=value
+func splitFlag(arg string) (identifier, value string, hasValue bool) {
+	index := strings.Index(arg, "=")
+	if index < 0 {
+		return arg, "", false
+	}
+
+	return arg[:index], arg[index+1:], true
+}
+
+// matchCluster returns flag instances for clustered short flags, ie: -vvv or -vn
+// Every character must match a single character BOOL or COUNT identifier, otherwise returns nil
+func matchCluster(arg string, allowedFlags map[string]*Flag, flags map[string]*Flag) (cluster []*Flag) {
+	if len(arg) < 3 || strings.HasPrefix(arg, "--") {
+		return nil
+	}
+
+	// Track instances created by this cluster so repeats share a value
+	matched := map[string]*Flag{}
+	for _, char := range arg[1:] {
+		flag := matchFlag("-"+string(char), allowedFlags, flags)
+		if flag == nil || flag.Type != BOOL && flag.Type != COUNT {
+			return nil
+		}
+
+		if existing, ok := matched[flag.Name]; ok {
+			flag = existing
+		}
+
+		matched[flag.Name] = flag
+		cluster = append(cluster, flag)
+	}
+
+	return
 }
 
 // simpleParse returns a generically parsed argument structure, with default parsing rules:
@@ -312,7 +756,7 @@ func simpleParse(argV []string) *Command {
 					debug("  Command: ", *arg)
 				} else {
 					// Arg
-					command.Arguments = append(command.Arguments, &Argument{*arg, DEFAULT, false, *arg})
+					command.Arguments = append(command.Arguments, &Argument{Name: *arg, Type: DEFAULT, Value: *arg})
 					debug("  Argument: ", *arg)
 				}
 			default:
@@ -113,3 +113,19 @@ var nameOnlyFlag = Flag{
 	Type:        BOOL,
 	Value:       true,
 }
+
+// -v
+var vFlagName = "-v"
+var vConfigFlag = Flag{
+	Name:        vFlagName,
+	Identifiers: []string{vFlagName, "-verbose"},
+	Type:        COUNT,
+}
+
+// -n
+var nFlagName = "-n"
+var nConfigFlag = Flag{
+	Name:        nFlagName,
+	Identifiers: []string{nFlagName},
+	Type:        BOOL,
+}
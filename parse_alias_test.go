@@ -0,0 +1,83 @@
+package flag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func aliasParg() *Parg {
+	parg := New()
+	parg.AddCommand(Command{Action: "status", Aliases: []string{"st"}})
+	parg.AddCommand(Command{Action: "stash"})
+	parg.AddCommand(Command{Action: "list", Aliases: []string{"ls"}})
+	parg.AddGlobalFlag(bConfigFlag)
+	return parg
+}
+
+func TestConfigParse_CommandAlias(context *testing.T) {
+	input := "gomu -b JIRA-Ticket ls"
+	args := strings.Split(input, " ")
+
+	parg := aliasParg()
+	command, err := parg.validate(args)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Action, context, "Action should be <list>")
+	result = test.Equals("list")
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom(bFlagName), context, "-b should be JIRA-Ticket")
+	result = test.Equals("JIRA-Ticket")
+	test.Validate(result)
+}
+
+func TestConfigParse_CommandPrefix(context *testing.T) {
+	parg := aliasParg()
+
+	command, err := parg.validate(strings.Split("gomu li", " "))
+
+	test := simply.Target(err, context, "Prefix should not match without PrefixMatching")
+	result := test.DoesNotEqual(nil)
+	test.Validate(result)
+
+	parg.PrefixMatching = true
+	command, err = parg.validate(strings.Split("gomu li", " "))
+
+	test = simply.Target(err, context, "Error should not exist")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Action, context, "Action should be <list>")
+	result = test.Equals("list")
+	test.Validate(result)
+
+	command, err = parg.validate(strings.Split("gomu sta", " "))
+
+	test = simply.Target(err.Error(), context, "Error should name candidates")
+	result = test.Equals("ambiguous command <sta> encountered, could be: stash, status")
+	test.Validate(result)
+
+	test = simply.Target(command, context, "Command should not exist")
+	result = simply.Assert(test).Equals(nil)
+	test.Validate(result)
+}
+
+func TestHelp_CommandAlias(context *testing.T) {
+	aliasParg()
+	command := &Command{Action: "help", Arguments: []*Argument{{Name: "st", Value: "st"}}}
+
+	help := command.Help(false)
+
+	test := simply.Target(strings.Contains(help, "Command: status"), context, "Help should resolve alias")
+	result := test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(help, "aliases: st"), context, "Help should show aliases")
+	result = test.Equals(true)
+	test.Validate(result)
+}
@@ -0,0 +1,151 @@
+package flag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func argumentParg(arguments ...*Argument) *Parg {
+	parg := New()
+	parg.AddCommand(Command{Action: deployAction, Arguments: arguments})
+	parg.AddGlobalFlag(branchConfigFlag)
+	return parg
+}
+
+func TestConfigParse_TypedArguments(context *testing.T) {
+	parg := argumentParg(
+		&Argument{Name: "force", Type: BOOL},
+		&Argument{Name: "replicas", Type: INT},
+		&Argument{Name: "labels", Type: MAP},
+	)
+
+	command, err := parg.validate(strings.Split("gomu deploy yes 3 env=prod -b main team=core", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Arguments[0].Value, context, "Bool argument should parse yes")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(command.Arguments[1].Value, context, "Int argument should be 3")
+	result = test.Equals(3)
+	test.Validate(result)
+
+	test = simply.Target(command.Arguments[2].Value, context, "Variadic map argument should collect remaining pairs")
+	result = test.Equals(map[string]string{"env": "prod", "team": "core"})
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom(bFlagName), context, "Flags should parse between variadic values")
+	result = test.Equals("main")
+	test.Validate(result)
+
+	test = simply.Target(len(command.Arguments), context, "Variadic values should share one argument")
+	result = test.Equals(3)
+	test.Validate(result)
+}
+
+func TestConfigParse_VariadicArgument(context *testing.T) {
+	modules := &Argument{Name: "modules", Type: STRINGS, Required: true}
+	parg := argumentParg(&Argument{Name: "env", Required: true}, modules)
+
+	command, err := parg.validate(strings.Split("gomu deploy prod mod-common simply", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Arguments[1].Value, context, "Modules should collect remaining tokens")
+	result = test.Equals([]string{"mod-common", "simply"})
+	test.Validate(result)
+
+	_, err = parg.validate(strings.Split("gomu deploy dev parg", " "))
+
+	test = simply.Target(err, context, "Error should not exist")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(modules.Value, context, "Definitions should not be populated")
+	result = test.Equals(nil)
+	test.Validate(result)
+
+	parg = argumentParg(&Argument{Name: "ports", Type: INTS})
+	_, err = parg.validate(strings.Split("gomu deploy 80 443 http", " "))
+
+	test = simply.Target(err, context, "Variadic values should be type checked")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+}
+
+func TestConfigParse_TypedArguments_Error(context *testing.T) {
+	inputs := map[string]*Argument{
+		"gomu deploy maybe":      {Name: "force", Type: BOOL},
+		"gomu deploy many":       {Name: "retries", Type: COUNT},
+		"gomu deploy api=three":  {Name: "replicas", Type: INTMAP},
+		"gomu deploy env env=a":  {Name: "labels", Type: MAP},
+		"gomu deploy on off":     {Name: "force", Type: BOOL},
+		"gomu deploy a=1 a=true": {Name: "features", Type: BOOLMAP},
+	}
+
+	for input, argument := range inputs {
+		parg := argumentParg(argument)
+		_, err := parg.validate(strings.Split(input, " "))
+
+		test := simply.Target(err, context, "Error should exist for "+input)
+		result := test.DoesNotEqual(nil)
+		test.Validate(result)
+	}
+}
+
+func TestCommand_ArgumentAccessors(context *testing.T) {
+	parg := argumentParg(
+		&Argument{Name: "module"},
+		&Argument{Name: "replicas", Type: INT},
+		&Argument{Name: "ids", Type: INTS},
+	)
+
+	command, err := parg.validate(strings.Split("gomu deploy mod-common 3 1 2", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	module, err := command.ArgString("module")
+
+	test = simply.Target(module, context, "Module should be found by name")
+	result = test.Equals("mod-common")
+	test.Validate(result)
+
+	ids, err := command.ArgInts("ids")
+
+	test = simply.Target(ids, context, "Ids should be found by name")
+	result = test.Equals([]int{1, 2})
+	test.Validate(result)
+
+	argument, ok := command.ArgAt(1)
+
+	test = simply.Target(ok && argument.Value == 3, context, "Replicas should be found by index")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	_, err = command.ArgString("replicas")
+
+	test = simply.Target(err.Error(), context, "Mistyped values should return an error")
+	result = test.Equals("argument <replicas> is int, not string")
+	test.Validate(result)
+
+	_, err = command.ArgBool("force")
+
+	test = simply.Target(err.Error(), context, "Missing values should return an error")
+	result = test.Equals("argument <force> not provided")
+	test.Validate(result)
+
+	_, ok = command.ArgAt(3)
+
+	test = simply.Target(ok, context, "Index out of range should not be found")
+	result = test.Equals(false)
+	test.Validate(result)
+}
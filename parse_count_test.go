@@ -0,0 +1,75 @@
+package flag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestConfigParse_CountFlag_Repeated(context *testing.T) {
+	input := "gomu -v -verbose sync -v"
+	args := strings.Split(input, " ")
+
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(vConfigFlag)
+
+	command, err := parg.validate(args)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.CountFrom(vFlagName), context, "Count should be 3")
+	result = test.Equals(3)
+	test.Validate(result)
+}
+
+func TestConfigParse_CountFlag_Clustered(context *testing.T) {
+	input := "gomu -vvv -n sync -vn"
+	args := strings.Split(input, " ")
+
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(vConfigFlag)
+	parg.AddGlobalFlag(nConfigFlag)
+
+	command, err := parg.validate(args)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.CountFrom(vFlagName), context, "Count should be 4")
+	result = test.Equals(4)
+	test.Validate(result)
+
+	test = simply.Target(command.BoolFrom(nFlagName), context, "Bool flag should be set")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(command.Action, context, "Action should be <sync>")
+	result = test.Equals(syncAction)
+	test.Validate(result)
+}
+
+func TestConfigParse_CountFlag_ClusterError(context *testing.T) {
+	input := "gomu -vb sync"
+	args := strings.Split(input, " ")
+
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(vConfigFlag)
+	parg.AddGlobalFlag(bConfigFlag)
+
+	command, err := parg.validate(args)
+
+	test := simply.Target(err, context, "Error should exist for cluster containing value flag")
+	result := test.DoesNotEqual(nil)
+	test.Validate(result)
+
+	test = simply.Target(command, context, "Command should not exist")
+	result = simply.Assert(test).Equals(nil)
+	test.Validate(result)
+}
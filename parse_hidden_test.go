@@ -0,0 +1,93 @@
+package flag
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestConfigParse_DeprecatedFlag(context *testing.T) {
+	input := "gomu -i hatchify sync -i vroomy"
+	args := strings.Split(input, " ")
+
+	includeFlag := includeConfigFlag
+	includeFlag.Deprecated = "use -org instead"
+
+	var warnings bytes.Buffer
+	parg := New()
+	parg.Warnings = &warnings
+	parg.AddCommand(Command{Action: syncAction})
+	parg.AddGlobalFlag(includeFlag)
+
+	command, err := parg.validate(args)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.StringsFrom(iFlagName), context, "Deprecated flag should be parsed")
+	result = test.Equals([]string{"hatchify", "vroomy"})
+	test.Validate(result)
+
+	test = simply.Target(warnings.String(), context, "Warning should be written once")
+	result = test.Equals("Warning: flag <-i> is deprecated: use -org instead\n")
+	test.Validate(result)
+}
+
+func TestConfigParse_DeprecatedCommand(context *testing.T) {
+	var warnings bytes.Buffer
+	parg := New()
+	parg.Warnings = &warnings
+	parg.AddCommand(Command{Action: deployAction, Deprecated: "use sync instead"})
+
+	command, err := parg.validate(strings.Split("gomu deploy", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Action, context, "Deprecated command should be parsed")
+	result = test.Equals(deployAction)
+	test.Validate(result)
+
+	test = simply.Target(warnings.String(), context, "Warning should name replacement")
+	result = test.Equals("Warning: command <deploy> is deprecated: use sync instead\n")
+	test.Validate(result)
+}
+
+func TestHelp_Hidden(context *testing.T) {
+	hiddenFlag := bConfigFlag
+	hiddenFlag.Hidden = true
+
+	parg := New()
+	parg.AddCommand(Command{Action: syncAction})
+	parg.AddCommand(Command{Action: deployAction, Hidden: true})
+	parg.AddGlobalFlag(hiddenFlag)
+	parg.AddGlobalFlag(nameOnlyConfigFlag)
+
+	command, err := parg.validate(strings.Split("gomu deploy -b JIRA-Ticket", " "))
+
+	test := simply.Target(err, context, "Hidden items should be parsed")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom(bFlagName), context, "Hidden flag should be parsed")
+	result = test.Equals("JIRA-Ticket")
+	test.Validate(result)
+
+	help := Help(false)
+
+	test = simply.Target(strings.Contains(help, deployAction), context, "Help should omit hidden command")
+	result = test.Equals(false)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(help, "[-b]"), context, "Help should omit hidden flag")
+	result = test.Equals(false)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(help, nameOnlyFlagName), context, "Help should show visible flag")
+	result = test.Equals(true)
+	test.Validate(result)
+}
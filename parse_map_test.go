@@ -0,0 +1,74 @@
+package flag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+var labelConfigFlag = Flag{
+	Name:        "-label",
+	Identifiers: []string{"-label", "-l"},
+	Type:        MAP,
+}
+
+var replicasConfigFlag = Flag{
+	Name:        "-replicas",
+	Identifiers: []string{"-replicas"},
+	Type:        INTMAP,
+}
+
+func TestConfigParse_MapFlag(context *testing.T) {
+	input := "gomu deploy -label env=prod team=core mod-common -l=tier=web -replicas api=3"
+	args := strings.Split(input, " ")
+
+	parg := New()
+	parg.AddAction(deployAction, "")
+	parg.AddGlobalFlag(labelConfigFlag)
+	parg.AddGlobalFlag(replicasConfigFlag)
+
+	command, err := parg.validate(args)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.MapFrom("-label"), context, "Labels should contain 3 pairs")
+	result = test.Equals(map[string]string{"env": "prod", "team": "core", "tier": "web"})
+	test.Validate(result)
+
+	test = simply.Target(command.IntMapFrom("-replicas"), context, "Replicas should contain api=3")
+	result = test.Equals(map[string]int{"api": 3})
+	test.Validate(result)
+
+	test = simply.Target(command.Args(), context, "Arguments should be [mod-common]")
+	result = test.Equals([]string{"mod-common"})
+	test.Validate(result)
+}
+
+func TestConfigParse_MapFlag_Error(context *testing.T) {
+	parg := New()
+	parg.AddAction(deployAction, "")
+	parg.AddGlobalFlag(labelConfigFlag)
+	parg.AddGlobalFlag(replicasConfigFlag)
+
+	inputs := []string{
+		"gomu deploy -label env",
+		"gomu deploy -label env=prod env=dev",
+		"gomu deploy -label =prod",
+		"gomu deploy -replicas api=three",
+	}
+
+	for _, input := range inputs {
+		command, err := parg.validate(strings.Split(input, " "))
+
+		test := simply.Target(err, context, "Error should exist for <"+input+">")
+		result := test.DoesNotEqual(nil)
+		test.Validate(result)
+
+		test = simply.Target(command, context, "Command should not exist for <"+input+">")
+		result = simply.Assert(test).Equals(nil)
+		test.Validate(result)
+	}
+}
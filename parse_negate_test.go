@@ -0,0 +1,95 @@
+package flag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+var cacheConfigFlag = Flag{
+	Name:        "--cache",
+	Identifiers: []string{"--cache"},
+	Type:        BOOL,
+}
+
+func TestConfigParse_NegatedBoolFlag(context *testing.T) {
+	input := "gomu -name-only sync --no-cache -no-name-only"
+	args := strings.Split(input, " ")
+
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(nameOnlyConfigFlag)
+	parg.AddGlobalFlag(cacheConfigFlag)
+
+	command, err := parg.validate(args)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Flags[nameOnlyFlagName].Value, context, "-name-only should be negated")
+	result = test.Equals(false)
+	test.Validate(result)
+
+	test = simply.Target(command.Flags["--cache"].Value, context, "--cache should be negated")
+	result = test.Equals(false)
+	test.Validate(result)
+}
+
+func TestConfigParse_ExplicitFlagValues(context *testing.T) {
+	input := "gomu -name-only=false sync -b=JIRA-Ticket -v=2"
+	args := strings.Split(input, " ")
+
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(nameOnlyConfigFlag)
+	parg.AddGlobalFlag(bConfigFlag)
+	parg.AddGlobalFlag(vConfigFlag)
+
+	command, err := parg.validate(args)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Flags[nameOnlyFlagName].Value, context, "-name-only should be false")
+	result = test.Equals(false)
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom(bFlagName), context, "-b should be JIRA-Ticket")
+	result = test.Equals("JIRA-Ticket")
+	test.Validate(result)
+
+	test = simply.Target(command.CountFrom(vFlagName), context, "-v should be 2")
+	result = test.Equals(2)
+	test.Validate(result)
+}
+
+func TestConfigParse_ExplicitFlagValues_Error(context *testing.T) {
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(nameOnlyConfigFlag)
+	parg.AddGlobalFlag(bConfigFlag)
+
+	for _, input := range []string{"gomu -name-only=maybe sync", "gomu -no-b sync"} {
+		command, err := parg.validate(strings.Split(input, " "))
+
+		test := simply.Target(err, context, "Error should exist for <"+input+">")
+		result := test.DoesNotEqual(nil)
+		test.Validate(result)
+
+		test = simply.Target(command, context, "Command should not exist for <"+input+">")
+		result = simply.Assert(test).Equals(nil)
+		test.Validate(result)
+	}
+}
+
+func TestHelp_NegatedBoolFlag(context *testing.T) {
+	parg := New()
+	parg.AddGlobalFlag(nameOnlyConfigFlag)
+
+	test := simply.Target(strings.Contains(Help(false), "[-name-only -no-name-only]"), context, "Help should show negated form")
+	result := test.Equals(true)
+	test.Validate(result)
+}
@@ -0,0 +1,271 @@
+package flag
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// require ensures required arguments and flags were provided
+// Missing values are prompted for when prompt and Interactive are set and Stdin is a terminal, otherwise returns an error
+// Returns an ErrorList of every missing value if CollectErrors is set
+func (p *Parg) require(command *Command, args []*Argument, flags map[string]*Flag, prompt bool) ([]*Argument, error) {
+	var pr *prompter
+	if prompt && p.Interactive && p.isTerminal() {
+		pr = p.newPrompter()
+	}
+
+	var missing ErrorList
+
+	if command != nil {
+		for i := len(args); i < len(command.Arguments); i++ {
+			definition := command.Arguments[i]
+			if !definition.Required {
+				break
+			}
+
+			if pr == nil {
+				err := fmt.Errorf("missing required argument <" + definition.Name + ">" + p.usage(command))
+				if !p.CollectErrors {
+					return nil, err
+				}
+
+				missing = append(missing, err)
+				continue
+			}
+
+			argument := definition.instance()
+			if err := pr.argument(argument); err != nil {
+				return nil, err
+			}
+
+			args = append(args, argument)
+		}
+	}
+
+	definitions := []*Flag{}
+	for i := range p.GlobalFlags {
+		definitions = append(definitions, &p.GlobalFlags[i])
+	}
+
+	if command != nil {
+		for _, name := range sortedFlagNames(command.Flags) {
+			definitions = append(definitions, command.Flags[name])
+		}
+	}
+
+	for _, definition := range definitions {
+		if _, ok := flags[definition.Name]; ok || !definition.Required {
+			continue
+		}
+
+		if pr == nil {
+			err := fmt.Errorf("missing required flag <" + definition.Name + ">" + p.usage(command))
+			if !p.CollectErrors {
+				return nil, err
+			}
+
+			missing = append(missing, err)
+			continue
+		}
+
+		flag := definition.instance()
+		if err := pr.flag(flag); err != nil {
+			return nil, err
+		}
+
+		p.addFlag(flag, flags)
+	}
+
+	if len(missing) > 0 {
+		return nil, missing
+	}
+
+	return args, nil
+}
+
+// isTerminal returns true if Stdin is an interactive terminal
+func (p *Parg) isTerminal() bool {
+	if p.terminal != nil {
+		return p.terminal(p.stdin())
+	}
+
+	return isTerminal(p.stdin())
+}
+
+// stdin returns the configured reader for prompts
+func (p *Parg) stdin() io.Reader {
+	if p.Stdin != nil {
+		return p.Stdin
+	}
+
+	return os.Stdin
+}
+
+// newPrompter returns a prompter reading from Stdin and writing to Prompts
+func (p *Parg) newPrompter() *prompter {
+	var pr prompter
+	pr.in = bufio.NewReader(p.stdin())
+	pr.out = os.Stderr
+	if p.Prompts != nil {
+		pr.out = p.Prompts
+	}
+
+	if file, ok := p.stdin().(*os.File); ok && isTerminal(file) {
+		// Only real terminals can mask input
+		pr.file = file
+	}
+
+	return &pr
+}
+
+// isTerminal returns true if v is a file for an interactive terminal
+func isTerminal(v interface{}) bool {
+	file, ok := v.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// prompter reads missing values from an interactive terminal
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	// file is set when input can be masked
+	file *os.File
+}
+
+// flag prompts until a valid value is parsed for flag
+func (pr *prompter) flag(flag *Flag) error {
+	for {
+		line, err := pr.ask(flag.Name, flag.Type, flag.Choices, flag.Sensitive)
+		if err != nil {
+			return fmt.Errorf("missing required flag <" + flag.Name + ">: " + err.Error())
+		}
+
+		if err = parsePrompted(flag, line); err == nil {
+			return nil
+		}
+
+		flag.Value = nil
+		fmt.Fprintln(pr.out, err)
+	}
+}
+
+// argument prompts until a valid value is parsed for arg
+func (pr *prompter) argument(arg *Argument) error {
+	for {
+		line, err := pr.ask(arg.Name, arg.Type, arg.Choices, false)
+		if err != nil {
+			return fmt.Errorf("missing required argument <" + arg.Name + ">: " + err.Error())
+		}
+
+		if len(line) == 0 {
+			fmt.Fprintln(pr.out, "A value is required for argument <"+arg.Name+">")
+			continue
+		}
+
+		if err = parsePromptedArgument(arg, line); err == nil {
+			return nil
+		}
+
+		arg.Value = nil
+		fmt.Fprintln(pr.out, err)
+	}
+}
+
+// parsePrompted parses a prompted line into flag, splitting multiple values on whitespace
+func parsePrompted(flag *Flag, line string) error {
+	switch flag.Type {
+	case STRINGS, INTS, MAP, INTMAP, BOOLMAP:
+		values := strings.Fields(line)
+		if len(values) == 0 {
+			return fmt.Errorf("A value is required for flag <" + flag.Name + ">, expected " + flag.Type.Expects())
+		}
+
+		for _, value := range values {
+			if err := flag.Parse(value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case DEFAULT, INT:
+		if len(line) == 0 {
+			return fmt.Errorf("A value is required for flag <" + flag.Name + ">, expected " + flag.Type.Expects())
+		}
+	}
+
+	return flag.assign(line)
+}
+
+// parsePromptedArgument parses a prompted line into arg, splitting variadic values on whitespace
+func parsePromptedArgument(arg *Argument, line string) error {
+	if !arg.Type.isVariadic() {
+		return arg.Parse(line)
+	}
+
+	for _, value := range strings.Fields(line) {
+		if err := arg.Parse(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ask prints a prompt, or a menu if choices are provided, and reads a line of input
+// Choices may be selected by number or value
+func (pr *prompter) ask(name string, argType ArgType, choices []string, secret bool) (line string, err error) {
+	if len(choices) > 0 {
+		fmt.Fprintf(pr.out, "Select <%s>:\n", name)
+		for i, choice := range choices {
+			fmt.Fprintf(pr.out, "  %d) %s\n", i+1, choice)
+		}
+		fmt.Fprint(pr.out, "> ")
+	} else {
+		fmt.Fprintf(pr.out, "Enter <%s> (%s): ", name, argType.Expects())
+	}
+
+	if secret {
+		line, err = pr.readSecret()
+	} else {
+		line, err = pr.readLine()
+	}
+
+	if err != nil {
+		return
+	}
+
+	if index, convErr := strconv.Atoi(line); convErr == nil && index > 0 && index <= len(choices) {
+		line = choices[index-1]
+	}
+
+	return
+}
+
+// readLine reads a line of input without the trailing newline
+func (pr *prompter) readLine() (string, error) {
+	line, err := pr.in.ReadString('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// readSecret reads a line of input with terminal echo disabled
+// Input already buffered, or not from a terminal, can't be masked and is read as is
+func (pr *prompter) readSecret() (string, error) {
+	if pr.file == nil || pr.in.Buffered() > 0 {
+		return pr.readLine()
+	}
+
+	defer fmt.Fprintln(pr.out)
+	line, err := term.ReadPassword(int(pr.file.Fd()))
+	return string(line), err
+}
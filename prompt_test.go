@@ -0,0 +1,104 @@
+package flag
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func promptParg(input string, terminal bool) (*Parg, *bytes.Buffer) {
+	var prompts bytes.Buffer
+	parg := New()
+	parg.Interactive = true
+	parg.Stdin = strings.NewReader(input)
+	parg.Prompts = &prompts
+	parg.terminal = func(r io.Reader) bool { return terminal }
+
+	parg.AddCommand(Command{
+		Action: deployAction,
+		Arguments: []*Argument{
+			{Name: "module", Required: true},
+			{Name: "replicas", Type: INT, Required: true},
+		},
+	})
+	parg.AddGlobalFlag(Flag{
+		Name:        "-env",
+		Identifiers: []string{"-env"},
+		Required:    true,
+		Choices:     []string{"dev", "prod"},
+	})
+	parg.AddGlobalFlag(Flag{
+		Name:        "-token",
+		Identifiers: []string{"-token"},
+		Required:    true,
+		Sensitive:   true,
+	})
+
+	return parg, &prompts
+}
+
+func TestPrompt_MissingValues(context *testing.T) {
+	// Replicas re-prompts after invalid input, env is selected by number
+	parg, prompts := promptParg("three\n3\n2\nsecret\n", true)
+
+	command, err := parg.validate(strings.Split("gomu deploy mod-common", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Arguments[1].Value, context, "Replicas should be prompted")
+	result = test.Equals(3)
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom("-env"), context, "Env should be selected from menu")
+	result = test.Equals("prod")
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom("-token"), context, "Token should be prompted")
+	result = test.Equals("secret")
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(prompts.String(), "  2) prod\n"), context, "Choices should be listed")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(prompts.String(), "Cannot set <three> for INT type argument <replicas>"), context, "Invalid input should be reported")
+	result = test.Equals(true)
+	test.Validate(result)
+}
+
+func TestPrompt_NotTerminal(context *testing.T) {
+	parg, prompts := promptParg("3\nprod\nsecret\n", false)
+
+	command, err := parg.validate(strings.Split("gomu deploy mod-common", " "))
+
+	test := simply.Target(strings.HasPrefix(err.Error(), "missing required argument <replicas>\nUsage: "), context, "Error should name missing argument")
+	result := test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(command, context, "Command should not exist")
+	result = simply.Assert(test).Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(prompts.String(), context, "Nothing should be prompted")
+	result = test.Equals("")
+	test.Validate(result)
+}
+
+func TestPrompt_EndOfInput(context *testing.T) {
+	parg, _ := promptParg("3\n", true)
+
+	command, err := parg.validate(strings.Split("gomu deploy mod-common", " "))
+
+	test := simply.Target(err, context, "Error should exist when input ends")
+	result := test.DoesNotEqual(nil)
+	test.Validate(result)
+
+	test = simply.Target(command, context, "Command should not exist")
+	result = simply.Assert(test).Equals(nil)
+	test.Validate(result)
+}
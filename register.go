@@ -0,0 +1,242 @@
+package flag
+
+import (
+	"reflect"
+	"strings"
+)
+
+// GetCommand returns the configured command for action, for registering command flags
+// Returns nil if no command matches action
+func (p *Parg) GetCommand(action string) *Command {
+	return p.command(action)
+}
+
+// command returns the configured command for action, or nil if no command matches action
+func (p *Parg) command(action string) *Command {
+	for i := range p.AllowedCommands {
+		if p.AllowedCommands[i].Action == action {
+			return &p.AllowedCommands[i]
+		}
+	}
+
+	return nil
+}
+
+// String registers a global DEFAULT flag, ie: p.String("-b", "branch", "", "Branch to checkout") for -b and -branch
+// Returns a pointer filled with the parsed value after parsing, or value if the flag is not provided
+func (p *Parg) String(identifier, name, value, usage string) *string {
+	target := value
+	p.AddGlobalFlag(newFlag(identifier, name, DEFAULT, value, usage, &target))
+	return &target
+}
+
+// Strings registers a global STRINGS flag, returning a pointer filled after parsing
+func (p *Parg) Strings(identifier, name string, value []string, usage string) *[]string {
+	target := value
+	p.AddGlobalFlag(newFlag(identifier, name, STRINGS, value, usage, &target))
+	return &target
+}
+
+// Int registers a global INT flag, returning a pointer filled after parsing
+func (p *Parg) Int(identifier, name string, value int, usage string) *int {
+	target := value
+	p.AddGlobalFlag(newFlag(identifier, name, INT, value, usage, &target))
+	return &target
+}
+
+// Ints registers a global INTS flag, returning a pointer filled after parsing
+func (p *Parg) Ints(identifier, name string, value []int, usage string) *[]int {
+	target := value
+	p.AddGlobalFlag(newFlag(identifier, name, INTS, value, usage, &target))
+	return &target
+}
+
+// Bool registers a global BOOL flag, returning a pointer filled after parsing
+func (p *Parg) Bool(identifier, name string, value bool, usage string) *bool {
+	target := value
+	p.AddGlobalFlag(newFlag(identifier, name, BOOL, value, usage, &target))
+	return &target
+}
+
+// Count registers a global COUNT flag, returning a pointer filled with the occurrences after parsing
+func (p *Parg) Count(identifier, name string, usage string) *int {
+	var target int
+	p.AddGlobalFlag(newFlag(identifier, name, COUNT, target, usage, &target))
+	return &target
+}
+
+// Map registers a global MAP flag, returning a pointer filled after parsing
+func (p *Parg) Map(identifier, name string, value map[string]string, usage string) *map[string]string {
+	target := value
+	p.AddGlobalFlag(newFlag(identifier, name, MAP, value, usage, &target))
+	return &target
+}
+
+// IntMap registers a global INTMAP flag, returning a pointer filled after parsing
+func (p *Parg) IntMap(identifier, name string, value map[string]int, usage string) *map[string]int {
+	target := value
+	p.AddGlobalFlag(newFlag(identifier, name, INTMAP, value, usage, &target))
+	return &target
+}
+
+// BoolMap registers a global BOOLMAP flag, returning a pointer filled after parsing
+func (p *Parg) BoolMap(identifier, name string, value map[string]bool, usage string) *map[string]bool {
+	target := value
+	p.AddGlobalFlag(newFlag(identifier, name, BOOLMAP, value, usage, &target))
+	return &target
+}
+
+// String registers a DEFAULT flag for this command only, returning a pointer filled after parsing
+func (cmd *Command) String(identifier, name, value, usage string) *string {
+	target := value
+	cmd.addFlag(newFlag(identifier, name, DEFAULT, value, usage, &target))
+	return &target
+}
+
+// Strings registers a STRINGS flag for this command only, returning a pointer filled after parsing
+func (cmd *Command) Strings(identifier, name string, value []string, usage string) *[]string {
+	target := value
+	cmd.addFlag(newFlag(identifier, name, STRINGS, value, usage, &target))
+	return &target
+}
+
+// Int registers an INT flag for this command only, returning a pointer filled after parsing
+func (cmd *Command) Int(identifier, name string, value int, usage string) *int {
+	target := value
+	cmd.addFlag(newFlag(identifier, name, INT, value, usage, &target))
+	return &target
+}
+
+// Ints registers an INTS flag for this command only, returning a pointer filled after parsing
+func (cmd *Command) Ints(identifier, name string, value []int, usage string) *[]int {
+	target := value
+	cmd.addFlag(newFlag(identifier, name, INTS, value, usage, &target))
+	return &target
+}
+
+// Bool registers a BOOL flag for this command only, returning a pointer filled after parsing
+func (cmd *Command) Bool(identifier, name string, value bool, usage string) *bool {
+	target := value
+	cmd.addFlag(newFlag(identifier, name, BOOL, value, usage, &target))
+	return &target
+}
+
+// Count registers a COUNT flag for this command only, returning a pointer filled with the occurrences after parsing
+func (cmd *Command) Count(identifier, name string, usage string) *int {
+	var target int
+	cmd.addFlag(newFlag(identifier, name, COUNT, target, usage, &target))
+	return &target
+}
+
+// Map registers a MAP flag for this command only, returning a pointer filled after parsing
+func (cmd *Command) Map(identifier, name string, value map[string]string, usage string) *map[string]string {
+	target := value
+	cmd.addFlag(newFlag(identifier, name, MAP, value, usage, &target))
+	return &target
+}
+
+// IntMap registers an INTMAP flag for this command only, returning a pointer filled after parsing
+func (cmd *Command) IntMap(identifier, name string, value map[string]int, usage string) *map[string]int {
+	target := value
+	cmd.addFlag(newFlag(identifier, name, INTMAP, value, usage, &target))
+	return &target
+}
+
+// BoolMap registers a BOOLMAP flag for this command only, returning a pointer filled after parsing
+func (cmd *Command) BoolMap(identifier, name string, value map[string]bool, usage string) *map[string]bool {
+	target := value
+	cmd.addFlag(newFlag(identifier, name, BOOLMAP, value, usage, &target))
+	return &target
+}
+
+// addFlag adds a flag definition scoped to this command
+func (cmd *Command) addFlag(flag Flag) {
+	if cmd.Flags == nil {
+		cmd.Flags = map[string]*Flag{}
+	}
+
+	cmd.Flags[flag.Name] = &flag
+}
+
+// newFlag returns a flag definition for identifier, with name as a long identifier, ie: "-b" and "branch" for -b and -branch
+// Non-zero values are used as the flag's default
+func newFlag(identifier, name string, argType ArgType, value interface{}, usage string, target interface{}) Flag {
+	flag := Flag{
+		Name:        identifier,
+		Identifiers: []string{identifier},
+		Type:        argType,
+		Help:        usage,
+		target:      target,
+	}
+
+	if len(name) > 0 {
+		if !strings.HasPrefix(name, "-") {
+			name = "-" + name
+		}
+
+		if name != identifier {
+			flag.Identifiers = append(flag.Identifiers, name)
+		}
+	}
+
+	if !isZero(value) {
+		flag.Default = value
+	}
+
+	return flag
+}
+
+// isZero returns true for zero values, and empty slices and maps
+func isZero(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+
+	return v.IsZero()
+}
+
+// store fills registered targets of global flags and flags of command with parsed values
+func (p *Parg) store(command *Command, flags map[string]*Flag) {
+	for i := range p.GlobalFlags {
+		p.GlobalFlags[i].store(flags)
+	}
+
+	if command != nil {
+		for _, flag := range command.Flags {
+			flag.store(flags)
+		}
+	}
+}
+
+// store fills the flag's registered target with its parsed value, or its default if not provided
+func (flag *Flag) store(flags map[string]*Flag) {
+	if flag.target == nil {
+		return
+	}
+
+	value := flag.Default
+	if parsed, ok := flags[flag.Name]; ok {
+		value = parsed.Value
+	}
+
+	switch target := flag.target.(type) {
+	case *string:
+		*target, _ = value.(string)
+	case *[]string:
+		*target, _ = value.([]string)
+	case *int:
+		*target, _ = value.(int)
+	case *[]int:
+		*target, _ = value.([]int)
+	case *bool:
+		*target, _ = value.(bool)
+	case *map[string]string:
+		*target, _ = value.(map[string]string)
+	case *map[string]int:
+		*target, _ = value.(map[string]int)
+	case *map[string]bool:
+		*target, _ = value.(map[string]bool)
+	}
+}
@@ -0,0 +1,128 @@
+package flag
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestRegister_GlobalFlags(context *testing.T) {
+	parg := New()
+	parg.AddAction(syncAction, "")
+	branch := parg.String("-b", "branch", "master", "Branch to checkout")
+	include := parg.Strings("-i", "include", nil, "Modules to include")
+	verbose := parg.Count("-v", "", "Verbosity")
+	dryRun := parg.Bool("-n", "dry-run", false, "Print without running")
+	labels := parg.Map("-l", "label", nil, "Labels to apply")
+
+	_, err := parg.validate(strings.Split("gomu sync -branch main -i mod-common simply -vv -l env=prod", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(*branch, context, "Branch should be filled")
+	result = test.Equals("main")
+	test.Validate(result)
+
+	test = simply.Target(*include, context, "Include should be filled")
+	result = test.Equals([]string{"mod-common", "simply"})
+	test.Validate(result)
+
+	test = simply.Target(*verbose, context, "Verbosity should be counted")
+	result = test.Equals(2)
+	test.Validate(result)
+
+	test = simply.Target(*labels, context, "Labels should be filled")
+	result = test.Equals(map[string]string{"env": "prod"})
+	test.Validate(result)
+
+	test = simply.Target(*dryRun, context, "Absent bool should be false")
+	result = test.Equals(false)
+	test.Validate(result)
+
+	_, err = parg.validate(strings.Split("gomu sync -n", " "))
+
+	test = simply.Target(err, context, "Error should not exist")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(*branch, context, "Absent flag should reset to default")
+	result = test.Equals("master")
+	test.Validate(result)
+
+	test = simply.Target(*dryRun, context, "Dry run should be filled")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(parg.GlobalFlags[0].Identifiers, context, "Name should be added as a long identifier")
+	result = test.Equals([]string{"-b", "-branch"})
+	test.Validate(result)
+}
+
+func TestRegister_CommandFlags(context *testing.T) {
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddAction(deployAction, "")
+	replicas := parg.GetCommand(deployAction).Int("-r", "replicas", 1, "Replicas to deploy")
+
+	_, err := parg.validate(strings.Split("gomu deploy -replicas 3", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(*replicas, context, "Command flag should be filled")
+	result = test.Equals(3)
+	test.Validate(result)
+
+	_, err = parg.validate(strings.Split("gomu sync -r 3", " "))
+
+	test = simply.Target(err, context, "Command flags should not be allowed for other commands")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+
+	_, err = parg.validate(strings.Split("gomu -r 3 deploy", " "))
+
+	test = simply.Target(err, context, "Command flags should not be allowed before the command")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+
+	test = simply.Target(parg.Complete([]string{"deploy", "-re"}), context, "Command flags should complete after the command")
+	result = test.Equals([]string{"-replicas"})
+	test.Validate(result)
+}
+
+func TestCommandFlags_Definitions(context *testing.T) {
+	parg := New()
+	parg.AddCommand(Command{
+		Action: deployAction,
+		Flags: map[string]*Flag{
+			"-r": {Name: "-r", Identifiers: []string{"-r"}, Type: INT, Required: true},
+		},
+	})
+
+	command, err := parg.validate(strings.Split("gomu deploy -r 3", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.IntFrom("-r"), context, "Command flag should be parsed after its command")
+	result = test.Equals(3)
+	test.Validate(result)
+
+	_, err = parg.validate(strings.Split("gomu -r 3 deploy", " "))
+
+	test = simply.Target(errors.Is(err, ErrInvalidFlag), context, "Command flag should be rejected before its command")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	_, err = parg.validate(strings.Split("gomu deploy", " "))
+
+	test = simply.Target(errors.Is(err, ErrMissingRequired), context, "Required command flag should be checked")
+	result = test.Equals(true)
+	test.Validate(result)
+}
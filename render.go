@@ -0,0 +1,160 @@
+package flag
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ANSI escape codes for rendered errors
+const (
+	colorError = "\x1b[1;31m"
+	colorCaret = "\x1b[31m"
+	colorReset = "\x1b[0m"
+)
+
+// PrintError writes err rendered by FormatError to w, with color if w is a terminal and NO_COLOR is not set
+func (p *Parg) PrintError(w io.Writer, argV []string, err error) {
+	color := isTerminal(w) && len(os.Getenv("NO_COLOR")) == 0
+	fmt.Fprint(w, p.FormatError(argV, err, color))
+}
+
+// FormatError renders err returned by parsing argV, reprinting the command line with a caret under each failing token:
+//
+//	error: Invalid value encountered. Cannot set <abc> for INT flag <-n>
+//	  gomu sync -n abc
+//	               ^^^
+//	  expected a single integer
+//
+// Followed by the synopsis of the matched command and a hint to run help for it
+// Values of sensitive flags are redacted. Errors other than a *ParseError, or an ErrorList of them, are rendered without a caret
+// The command line is rendered after response files are expanded, as positions refer to it. Returns "" if err is nil
+func (p *Parg) FormatError(argV []string, err error, color bool) string {
+	if err == nil {
+		return ""
+	}
+
+	paint := func(code, text string) string {
+		if !color {
+			return text
+		}
+
+		return code + text + colorReset
+	}
+
+	var parseErrs []*ParseError
+	var list ErrorList
+	var parseErr *ParseError
+	if errors.As(err, &list) {
+		for _, err := range list {
+			if errors.As(err, &parseErr) {
+				parseErrs = append(parseErrs, parseErr)
+			}
+		}
+	} else if errors.As(err, &parseErr) {
+		parseErrs = append(parseErrs, parseErr)
+	}
+
+	if len(parseErrs) == 0 {
+		return paint(colorError, "error:") + " " + err.Error() + "\n"
+	}
+
+	if parseErrs[0].argV != nil {
+		argV = parseErrs[0].argV
+	}
+
+	line, offsets := p.commandLine(argV)
+
+	var msg, action string
+	for _, parseErr := range parseErrs {
+		offset, width := len(line)+1, 1
+		if parseErr.Position < len(offsets) {
+			offset, width = offsets[parseErr.Position][0], offsets[parseErr.Position][1]
+		}
+
+		msg += paint(colorError, "error:") + " " + withoutUsage(parseErr.Error()) + "\n"
+		msg += "  " + line + "\n"
+		msg += "  " + strings.Repeat(" ", offset) + paint(colorCaret, strings.Repeat("^", width)) + "\n"
+		if len(parseErr.Expects) > 0 {
+			msg += "  expected " + parseErr.Expects + "\n"
+		}
+
+		if len(parseErr.action) > 0 {
+			action = parseErr.action
+		}
+	}
+
+	program := os.Args[0]
+	if len(argV) > 0 {
+		program = argV[0]
+	}
+
+	if cmd := p.command(action); cmd != nil && len(action) > 0 {
+		msg += "\nUsage: " + p.synopsis(cmd, true) + "\n"
+	}
+
+	if hint := p.helpHint(program, action); len(hint) > 0 {
+		msg += hint + "\n"
+	}
+
+	return msg
+}
+
+// commandLine returns argV as a quoted command line with sensitive values redacted,
+// along with the offset and width of each token in the line
+func (p *Parg) commandLine(argV []string) (line string, offsets [][2]int) {
+	sensitive := p.sensitiveIdentifiers()
+	for i, token := range argV {
+		identifier, _, hasValue := splitFlag(token)
+		switch {
+		case i == 0:
+		case hasValue && sensitive[identifier]:
+			token = identifier + "=" + redacted
+		case sensitive[argV[i-1]] && (!strings.HasPrefix(token, "-") || token == "-"):
+			token = redacted
+		}
+
+		if i > 0 {
+			line += " "
+		}
+
+		token = quote(token)
+		offsets = append(offsets, [2]int{len(line), len(token)})
+		line += token
+	}
+
+	return
+}
+
+// helpHint returns a hint to run help for action, using the help command if configured, otherwise a help flag
+func (p *Parg) helpHint(program, action string) string {
+	if _, ok := p.GetAllowedCommands()["help"]; ok {
+		return "Run `" + strings.TrimSpace(program+" help "+action) + "`"
+	}
+
+	if helpFlags := p.helpFlags(); len(helpFlags) > 0 {
+		return "Run `" + strings.TrimSpace(program+" "+action) + " " + helpFlags[0] + "`"
+	}
+
+	return ""
+}
+
+// withoutUsage returns msg without a trailing usage line, which is rendered once for all errors
+func withoutUsage(msg string) string {
+	if index := strings.Index(msg, "\nUsage: "); index >= 0 {
+		return msg[:index]
+	}
+
+	return msg
+}
+
+// quote returns token quoted for a shell if it's empty or contains spaces, quotes or escapes
+func quote(token string) string {
+	if len(token) > 0 && !strings.ContainsAny(token, " \t\n'\"\\") {
+		return token
+	}
+
+	return "'" + strings.Replace(token, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,80 @@
+package flag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestFormatError(context *testing.T) {
+	parg := collectParg()
+	argV := strings.Split("gomu sync -x -retries=abc", " ")
+
+	_, err := parg.validate(argV)
+	lines := strings.Split(parg.FormatError(argV, err, false), "\n")
+
+	test := simply.Target(lines[:7], context, "Failing tokens should be underlined with the expected value")
+	result := test.Equals([]string{
+		"error: invalid flag <-x> encountered",
+		"  gomu sync -x -retries=abc",
+		"            ^^",
+		"error: Invalid value encountered. Cannot set <abc> for INT flag <-retries>",
+		"  gomu sync -x -retries=abc",
+		"               ^^^^^^^^^^^^",
+		"  expected a single integer",
+	})
+	test.Validate(result)
+
+	test = simply.Target(lines[9], context, "Missing values should be marked at the end of the line")
+	result = test.Equals("                            ^")
+	test.Validate(result)
+
+	test = simply.Target(strings.HasSuffix(lines[14], " sync <module> [-b <main|dev>] [-retries <int>] -env <env>"), context, "Synopsis should be rendered once")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(lines[15], context, "Help hint should use a help flag without a help command")
+	result = test.Equals("Run `gomu sync -h`")
+	test.Validate(result)
+
+	parg.AddGlobalFlag(tokenConfigFlag)
+	parg.AddAction("help", "Show help for a command")
+	argV = []string{"gomu", "sync", "mod-common", "-token", "hunter 2", "-b", "feature", "-env=dev"}
+
+	_, err = parg.validate(argV)
+	output := parg.FormatError(argV, err, true)
+
+	test = simply.Target(strings.Contains(output, "hunter"), context, "Sensitive values should be redacted")
+	result = test.Equals(false)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(output, colorError+"error:"+colorReset), context, "Errors should be colored")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(strings.HasSuffix(output, "\nRun `gomu help sync`\n"), context, "Help hint should use the help command")
+	result = test.Equals(true)
+	test.Validate(result)
+}
+
+func TestFormatError_ResponseFiles(context *testing.T) {
+	path := writeResponseFile(context, context.TempDir(), "args.txt", "-retries abc\n")
+	parg := collectParg()
+	parg.ResponseFiles = true
+	argV := []string{"gomu", "sync", "mod-common", "@" + path, "-env=dev"}
+
+	_, err := parg.validate(argV)
+	lines := strings.Split(parg.FormatError(argV, err, false), "\n")
+
+	test := simply.Target(lines[1:3], context, "Caret should point into the expanded command line")
+	result := test.Equals([]string{
+		"  gomu sync mod-common -retries abc -env=dev",
+		"                                ^^^",
+	})
+	test.Validate(result)
+
+	test = simply.Target(parg.FormatError(argV, nil, false), context, "Nil errors should not be rendered")
+	result = test.Equals("")
+	test.Validate(result)
+}
@@ -0,0 +1,104 @@
+package flag
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExpandResponseFiles replaces @path arguments with the arguments contained in the file at path
+// Files use shell-like quoting, # comments, and may reference other response files relative to their own directory
+// argV[0] is never expanded, and cycles between response files return an error
+func ExpandResponseFiles(argV []string) ([]string, error) {
+	return expandResponseFiles(argV, nil)
+}
+
+// expandResponseFiles expands @path arguments, skipping values following identifiers of sensitive flags
+func expandResponseFiles(argV []string, sensitive map[string]bool) (expanded []string, err error) {
+	if len(argV) == 0 {
+		return argV, nil
+	}
+
+	expanded = []string{argV[0]}
+	if expanded, err = expandArgs(expanded, argV[1:], "", nil, sensitive); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// expandArgs appends args to expanded, recursively expanding response files
+// Relative paths are resolved from dir, stack holds files currently being expanded
+func expandArgs(expanded []string, args []string, dir string, stack []string, sensitive map[string]bool) ([]string, error) {
+	for _, arg := range args {
+		if !isResponseFile(arg) || len(expanded) > 0 && sensitive[expanded[len(expanded)-1]] {
+			expanded = append(expanded, arg)
+			continue
+		}
+
+		path := arg[1:]
+		if !filepath.IsAbs(path) && len(dir) > 0 {
+			path = filepath.Join(dir, path)
+		}
+
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response file <" + arg + ">: " + err.Error())
+		}
+
+		for _, open := range stack {
+			if open == abs {
+				return nil, fmt.Errorf("invalid response file <" + arg + ">: cycle detected: " + strings.Join(append(stack, abs), " -> "))
+			}
+		}
+
+		data, err := os.ReadFile(abs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response file <" + arg + ">: " + err.Error())
+		}
+
+		words, err := tokenize(string(data), true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response file <" + arg + ">: " + err.Error())
+		}
+
+		if expanded, err = expandArgs(expanded, words, filepath.Dir(abs), append(stack, abs), sensitive); err != nil {
+			return nil, err
+		}
+	}
+
+	return expanded, nil
+}
+
+// isResponseFile returns true for @path arguments
+func isResponseFile(arg string) bool {
+	return len(arg) > 1 && arg[0] == '@'
+}
+
+// sensitiveIdentifiers returns identifiers of sensitive flags, whose @path values are read by the flag
+func (p *Parg) sensitiveIdentifiers() map[string]bool {
+	identifiers := map[string]bool{}
+	add := func(flag *Flag) {
+		if !flag.Sensitive {
+			return
+		}
+
+		for _, identifier := range flag.Identifiers {
+			identifiers[identifier] = true
+		}
+	}
+
+	for i := range p.GlobalFlags {
+		add(&p.GlobalFlags[i])
+	}
+
+	// The command isn't known before expansion, so command flags are included for every command
+	for _, cmd := range p.AllowedCommands {
+		for _, flag := range cmd.Flags {
+			add(flag)
+		}
+	}
+
+	return identifiers
+}
@@ -0,0 +1,101 @@
+package flag
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func writeResponseFile(context *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		context.Fatal(err)
+	}
+
+	return path
+}
+
+func TestResponseFiles_Expand(context *testing.T) {
+	dir := context.TempDir()
+	writeResponseFile(context, dir, "flags.txt", "# Shared flags\n-i hatchify 'vroomy' # trailing comment\n")
+	path := writeResponseFile(context, dir, "args.txt", "deploy mod-common @flags.txt\n-b \"JIRA Ticket\"\n")
+
+	args, err := ExpandResponseFiles([]string{"gomu", "-name-only", "@" + path, "simply"})
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(args, context, "Args should be expanded recursively")
+	result = test.Equals([]string{"gomu", "-name-only", "deploy", "mod-common", "-i", "hatchify", "vroomy", "-b", "JIRA Ticket", "simply"})
+	test.Validate(result)
+}
+
+func TestResponseFiles_Cycle(context *testing.T) {
+	dir := context.TempDir()
+	writeResponseFile(context, dir, "a.txt", "sync @b.txt")
+	writeResponseFile(context, dir, "b.txt", "@a.txt")
+
+	args, err := ExpandResponseFiles([]string{"gomu", "@" + filepath.Join(dir, "a.txt")})
+
+	test := simply.Target(err != nil && strings.Contains(err.Error(), "cycle detected"), context, "Cycle should error")
+	result := test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(args, context, "Args should not exist")
+	result = test.Equals(nil)
+	test.Validate(result)
+}
+
+func TestResponseFiles_Validate(context *testing.T) {
+	dir := context.TempDir()
+	path := writeResponseFile(context, dir, "args.txt", "-i hatchify vroomy\n")
+	token := writeResponseFile(context, dir, "token", "hunter2\n")
+
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(includeConfigFlag)
+	parg.AddGlobalFlag(tokenConfigFlag)
+
+	command, err := parg.validate([]string{"gomu", "sync", "@" + path})
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Args(), context, "Response files should not expand unless enabled")
+	result = test.Equals([]string{"@" + path})
+	test.Validate(result)
+
+	parg.ResponseFiles = true
+	command, err = parg.validate([]string{"gomu", "sync", "@" + path, "-token", "@" + token})
+
+	test = simply.Target(err, context, "Error should not exist")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.StringsFrom(iFlagName), context, "Flags should be parsed from response file")
+	result = test.Equals([]string{"hatchify", "vroomy"})
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom("-token"), context, "Sensitive flag should read its own file")
+	result = test.Equals("hunter2")
+	test.Validate(result)
+}
+
+func TestResponseFiles_Continuation(context *testing.T) {
+	path := writeResponseFile(context, context.TempDir(), "args.txt", "deploy mod-common \\\n\t-i hatchify \\\n    vroomy\n")
+
+	args, err := ExpandResponseFiles([]string{"gomu", "@" + path})
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(args, context, "Indented continuation lines should not add empty args")
+	result = test.Equals([]string{"gomu", "deploy", "mod-common", "-i", "hatchify", "vroomy"})
+	test.Validate(result)
+}
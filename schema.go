@@ -0,0 +1,299 @@
+package flag
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SchemaVersion is the version of the schema produced by Schema, and accepted by LoadSchema
+const SchemaVersion = "1"
+
+// Schema is a machine-readable description of a Parg configuration
+// Used by GUIs, wrappers and docs to consume a CLI definition
+type Schema struct {
+	// SchemaVersion of this description
+	SchemaVersion string `json:"schemaVersion"`
+	// Program name
+	Program string `json:"program,omitempty"`
+	// Version configured by AddVersion
+	Version string `json:"version,omitempty"`
+
+	// Parsing options
+	PrefixMatching bool     `json:"prefixMatching,omitempty"`
+	ResponseFiles  bool     `json:"responseFiles,omitempty"`
+	HelpFlags      []string `json:"helpFlags,omitempty"`
+
+	// Commands and GlobalFlags describe the allowed config
+	Commands    []CommandSchema `json:"commands"`
+	GlobalFlags []FlagSchema    `json:"globalFlags"`
+}
+
+// CommandSchema describes an allowed command
+type CommandSchema struct {
+	Action     string   `json:"action"`
+	Aliases    []string `json:"aliases,omitempty"`
+	Help       string   `json:"help,omitempty"`
+	Synopsis   string   `json:"synopsis"`
+	Hidden     bool     `json:"hidden,omitempty"`
+	Deprecated string   `json:"deprecated,omitempty"`
+	HasHandler bool     `json:"hasHandler"`
+
+	Arguments []ArgumentSchema `json:"arguments,omitempty"`
+	Flags     []FlagSchema     `json:"flags,omitempty"`
+}
+
+// ArgumentSchema describes an allowed positional argument
+type ArgumentSchema struct {
+	Name     string   `json:"name"`
+	Type     ArgType  `json:"type"`
+	Expects  string   `json:"expects"`
+	Required bool     `json:"required,omitempty"`
+	Variadic bool     `json:"variadic,omitempty"`
+	Choices  []string `json:"choices,omitempty"`
+	Help     string   `json:"help,omitempty"`
+}
+
+// FlagSchema describes an allowed flag
+type FlagSchema struct {
+	Name        string      `json:"name"`
+	Identifiers []string    `json:"identifiers"`
+	Negations   []string    `json:"negations,omitempty"`
+	Type        ArgType     `json:"type"`
+	Expects     string      `json:"expects"`
+	Help        string      `json:"help,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Sensitive   bool        `json:"sensitive,omitempty"`
+	Choices     []string    `json:"choices,omitempty"`
+	Hidden      bool        `json:"hidden,omitempty"`
+	Deprecated  string      `json:"deprecated,omitempty"`
+}
+
+// Schema returns a description of all configured commands, flags and arguments
+func (p *Parg) Schema() *Schema {
+	var schema Schema
+	schema.SchemaVersion = SchemaVersion
+	schema.Program = filepath.Base(os.Args[0])
+	schema.Version = p.version
+	schema.PrefixMatching = p.PrefixMatching
+	schema.ResponseFiles = p.ResponseFiles
+	schema.HelpFlags = p.helpFlags()
+	schema.Commands = []CommandSchema{}
+	schema.GlobalFlags = []FlagSchema{}
+
+	for i := range p.AllowedCommands {
+		schema.Commands = append(schema.Commands, p.commandSchema(&p.AllowedCommands[i]))
+	}
+
+	for i := range p.GlobalFlags {
+		schema.GlobalFlags = append(schema.GlobalFlags, flagSchema(&p.GlobalFlags[i]))
+	}
+
+	return &schema
+}
+
+// MarshalSchema returns the schema as indented JSON
+func (p *Parg) MarshalSchema() ([]byte, error) {
+	return json.MarshalIndent(p.Schema(), "", "  ")
+}
+
+// LoadSchema returns a new Parg configured from a JSON schema produced by MarshalSchema
+// Handlers are not part of the schema, attach them with Handle
+func LoadSchema(data []byte) (*Parg, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid schema: " + err.Error())
+	}
+
+	if schema.SchemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("invalid schema: unsupported schema version <" + schema.SchemaVersion + ">, expected " + SchemaVersion)
+	}
+
+	parg := New()
+	parg.PrefixMatching = schema.PrefixMatching
+	parg.ResponseFiles = schema.ResponseFiles
+	parg.HelpFlags = schema.HelpFlags
+	parg.DisableHelpFlags = len(schema.HelpFlags) == 0
+
+	for _, flagSchema := range schema.GlobalFlags {
+		flag, err := flagSchema.flag()
+		if err != nil {
+			return nil, err
+		}
+
+		parg.AddGlobalFlag(*flag)
+	}
+
+	for _, commandSchema := range schema.Commands {
+		var command Command
+		command.Action = commandSchema.Action
+		command.Aliases = commandSchema.Aliases
+		command.Hidden = commandSchema.Hidden
+		command.Deprecated = commandSchema.Deprecated
+		command.helpDetails = commandSchema.Help
+
+		for _, argumentSchema := range commandSchema.Arguments {
+			command.Arguments = append(command.Arguments, &Argument{
+				Name:     argumentSchema.Name,
+				Type:     argumentSchema.Type,
+				Required: argumentSchema.Required,
+				Choices:  argumentSchema.Choices,
+				Help:     argumentSchema.Help,
+			})
+		}
+
+		for _, flagSchema := range commandSchema.Flags {
+			flag, err := flagSchema.flag()
+			if err != nil {
+				return nil, err
+			}
+
+			if command.Flags == nil {
+				command.Flags = map[string]*Flag{}
+			}
+			command.Flags[flag.Name] = flag
+		}
+
+		parg.AddCommand(command)
+	}
+
+	if len(schema.Version) > 0 {
+		// Version command is built in
+		parg.version = schema.Version
+		parg.versioned = true
+		parg.Handle("version", parg.printVersion)
+	}
+
+	return parg, nil
+}
+
+// Handle sets the handler for a configured command, ie: after LoadSchema
+func (p *Parg) Handle(action string, handler Handler) error {
+	for i := range p.AllowedCommands {
+		if p.AllowedCommands[i].Action == action {
+			p.AllowedCommands[i].handler = handler
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid command <" + action + ">: not configured")
+}
+
+// commandSchema describes a command definition
+func (p *Parg) commandSchema(cmd *Command) (schema CommandSchema) {
+	schema.Action = cmd.Action
+	schema.Aliases = cmd.Aliases
+	schema.Help = cmd.helpDetails
+	schema.Synopsis = p.synopsis(cmd, true)
+	schema.Hidden = cmd.Hidden
+	schema.Deprecated = cmd.Deprecated
+	schema.HasHandler = cmd.handler != nil
+
+	for _, argument := range cmd.Arguments {
+		schema.Arguments = append(schema.Arguments, ArgumentSchema{
+			Name:     argument.Name,
+			Type:     argument.Type,
+			Expects:  argument.Type.Expects(),
+			Required: argument.Required,
+			Variadic: argument.Type.isVariadic(),
+			Choices:  argument.Choices,
+			Help:     argument.Help,
+		})
+	}
+
+	for _, name := range sortedFlagNames(cmd.Flags) {
+		schema.Flags = append(schema.Flags, flagSchema(cmd.Flags[name]))
+	}
+
+	return
+}
+
+// flagSchema describes a flag definition, omitting sensitive defaults
+func flagSchema(flag *Flag) (schema FlagSchema) {
+	schema.Name = flag.Name
+	schema.Identifiers = flag.Identifiers
+	schema.Negations = flag.negations()
+	schema.Type = flag.Type
+	schema.Expects = flag.Type.Expects()
+	schema.Help = flag.Help
+	schema.Required = flag.Required
+	schema.Sensitive = flag.Sensitive
+	schema.Choices = flag.Choices
+	schema.Hidden = flag.Hidden
+	schema.Deprecated = flag.Deprecated
+	if !flag.Sensitive {
+		schema.Default = flag.Default
+	}
+
+	return
+}
+
+// flag returns the flag definition described by the schema
+func (schema FlagSchema) flag() (*Flag, error) {
+	var flag Flag
+	flag.Name = schema.Name
+	flag.Identifiers = schema.Identifiers
+	flag.Type = schema.Type
+	flag.Help = schema.Help
+	flag.Required = schema.Required
+	flag.Sensitive = schema.Sensitive
+	flag.Choices = schema.Choices
+	flag.Hidden = schema.Hidden
+	flag.Deprecated = schema.Deprecated
+
+	if schema.Default != nil {
+		// Decode generic JSON values into the flag's type
+		data, err := json.Marshal(schema.Default)
+		if err != nil {
+			return nil, err
+		}
+
+		if flag.Default, err = decodeValue(flag.Type, data); err != nil {
+			return nil, fmt.Errorf("invalid schema: default for flag <" + flag.Name + ">: " + err.Error())
+		}
+	}
+
+	return &flag, nil
+}
+
+// decodeValue decodes JSON data as the value type parsed for argType
+func decodeValue(argType ArgType, data []byte) (value interface{}, err error) {
+	switch argType {
+	case BOOL:
+		var val bool
+		err = json.Unmarshal(data, &val)
+		value = val
+	case INT, COUNT:
+		var val int
+		err = json.Unmarshal(data, &val)
+		value = val
+	case INTS:
+		var val []int
+		err = json.Unmarshal(data, &val)
+		value = val
+	case STRINGS:
+		var val []string
+		err = json.Unmarshal(data, &val)
+		value = val
+	case MAP:
+		var val map[string]string
+		err = json.Unmarshal(data, &val)
+		value = val
+	case INTMAP:
+		var val map[string]int
+		err = json.Unmarshal(data, &val)
+		value = val
+	case BOOLMAP:
+		var val map[string]bool
+		err = json.Unmarshal(data, &val)
+		value = val
+	default:
+		var val string
+		err = json.Unmarshal(data, &val)
+		value = val
+	}
+
+	return
+}
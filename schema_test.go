@@ -0,0 +1,97 @@
+package flag
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func schemaParg() *Parg {
+	parg := New()
+	parg.PrefixMatching = true
+	parg.AddHandler(syncAction, func(cmd *Command) error { return nil }, "Sync modules")
+	parg.AddCommand(Command{
+		Action:  deployAction,
+		Aliases: []string{"d"},
+		Arguments: []*Argument{
+			{Name: "env", Required: true, Choices: []string{"dev", "prod"}},
+			{Name: "modules", Type: STRINGS},
+		},
+		Flags: map[string]*Flag{
+			"-replicas": {Name: "-replicas", Identifiers: []string{"-replicas"}, Type: INT, Default: 2},
+		},
+		Deprecated: "use sync instead",
+	})
+	parg.AddGlobalFlag(branchConfigFlag)
+	parg.AddGlobalFlag(nameOnlyConfigFlag)
+	parg.AddGlobalFlag(tokenConfigFlag)
+	parg.AddGlobalFlag(Flag{Name: "-i", Identifiers: []string{"-i"}, Type: STRINGS, Default: []string{"hatchify"}})
+	return parg
+}
+
+func TestSchema_Marshal(context *testing.T) {
+	data, err := schemaParg().MarshalSchema()
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	var schema map[string]interface{}
+	json.Unmarshal(data, &schema)
+
+	test = simply.Target(schema["schemaVersion"], context, "Schema should be versioned")
+	result = test.Equals(SchemaVersion)
+	test.Validate(result)
+
+	commands := schema["commands"].([]interface{})
+	sync := commands[0].(map[string]interface{})
+
+	test = simply.Target(sync["hasHandler"], context, "Handler presence should be described")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(string(data), "dev-token"), context, "Sensitive defaults should be omitted")
+	result = test.Equals(false)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(string(data), `"negations": [`), context, "Bool negations should be described")
+	result = test.Equals(true)
+	test.Validate(result)
+}
+
+func TestSchema_Load(context *testing.T) {
+	original := schemaParg()
+	data, _ := original.MarshalSchema()
+
+	loaded, err := LoadSchema(data)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	err = loaded.Handle(syncAction, func(cmd *Command) error { return nil })
+
+	test = simply.Target(err, context, "Handler should attach to loaded command")
+	result = test.Equals(nil)
+	test.Validate(result)
+
+	expected := original.Schema()
+	expected.GlobalFlags[2].Default = nil
+
+	test = simply.Target(loaded.Schema(), context, "Loaded schema should match original")
+	result = test.Equals(expected)
+	test.Validate(result)
+
+	replicas, ok := loaded.AllowedCommands[1].Flags["-replicas"].Default.(int)
+	test = simply.Target(ok && replicas == 2, context, "Defaults should be typed")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	_, err = LoadSchema([]byte(`{"schemaVersion": "0"}`))
+
+	test = simply.Target(err, context, "Unsupported versions should error")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+}
@@ -0,0 +1,113 @@
+package flag
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+var tokenConfigFlag = Flag{
+	Name:        "-token",
+	Identifiers: []string{"-token"},
+	Sensitive:   true,
+	Default:     "dev-token",
+	Help:        "API token",
+}
+
+func TestSensitive_Redacted(context *testing.T) {
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(tokenConfigFlag)
+
+	command, err := parg.validate(strings.Split("gomu sync -token hunter2", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom("-token"), context, "Token should be parsed")
+	result = test.Equals("hunter2")
+	test.Validate(result)
+
+	data, _ := json.Marshal(command)
+	test = simply.Target(strings.Contains(string(data), "hunter2"), context, "JSON should not contain token")
+	result = test.Equals(false)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(string(data), `"value":"******"`), context, "JSON should contain redacted value")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	help := Help(false)
+	test = simply.Target(strings.Contains(help, "API token (default: ******)"), context, "Help should redact default")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	_, err = parg.validate(strings.Split("gomu sync -token hunter2 -token=letmein", " "))
+	test = simply.Target(err, context, "Redundant value should error")
+	result = test.Assert().DoesNotEqual(nil)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(err.Error(), "hunter2") || strings.Contains(err.Error(), "letmein"), context, "Error should not contain token")
+	result = test.Equals(false)
+	test.Validate(result)
+
+	inputs := map[string]*Parg{
+		"gomu sync -token hunter1 -token hunter2":   parg,
+		"gomu deploy -token hunter1 -token hunter2": argumentParg(&Argument{Name: "env"}, &Argument{Name: "module"}),
+	}
+	inputs["gomu deploy -token hunter1 -token hunter2"].AddGlobalFlag(tokenConfigFlag)
+
+	for input, parg := range inputs {
+		_, err = parg.validate(strings.Split(input, " "))
+
+		test = simply.Target(errors.Is(err, ErrInvalidValue), context, "Repeated value should be invalid for "+input)
+		result = test.Equals(true)
+		test.Validate(result)
+
+		test = simply.Target(strings.Contains(err.Error(), "hunter"), context, "Error should not contain repeated token for "+input)
+		result = test.Equals(false)
+		test.Validate(result)
+	}
+}
+
+func TestSensitive_ReadFromFileAndStdin(context *testing.T) {
+	path := filepath.Join(context.TempDir(), "token")
+	os.WriteFile(path, []byte("from-file\n"), 0600)
+
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(tokenConfigFlag)
+	parg.Stdin = strings.NewReader("from-stdin\n")
+
+	command, err := parg.validate([]string{"gomu", "sync", "-token", "@" + path})
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom("-token"), context, "Token should be read from file")
+	result = test.Equals("from-file")
+	test.Validate(result)
+
+	command, err = parg.validate([]string{"gomu", "sync", "-token", "-"})
+
+	test = simply.Target(err, context, "Error should not exist")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom("-token"), context, "Token should be read from stdin")
+	result = test.Equals("from-stdin")
+	test.Validate(result)
+
+	_, err = parg.validate([]string{"gomu", "sync", "-token=@" + path + ".missing"})
+
+	test = simply.Target(err, context, "Missing file should error")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+}
@@ -0,0 +1,394 @@
+package flag
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// errExit is returned by Shell.Exec when the session should end
+var errExit = errors.New("exit")
+
+// shellBuiltins are handled by the shell rather than dispatched to commands
+var shellBuiltins = []string{"exit", "help", "history", "quit", "set", "unset"}
+
+// Shell reads command lines and dispatches them to the configured command handlers
+// Builtins are `help [command]`, `history` (re-run lines with !<number> or !!), `set [-flags]` for session global flags,
+// `unset <-flag>` to remove a session flag, and `exit` or `quit` to end the session
+// On a terminal, tab completes the word before the cursor and up and down browse previous lines
+type Shell struct {
+	// Prompt is printed before reading each line
+	Prompt string
+
+	parg    *Parg
+	history []string
+
+	// session global flags applied to every line, by flag name
+	session map[string]*Flag
+}
+
+// NewShell returns an interactive shell for the configured commands
+func (p *Parg) NewShell() *Shell {
+	var shell Shell
+	shell.Prompt = "> "
+	shell.parg = p
+	shell.history = []string{}
+	shell.session = map[string]*Flag{}
+	return &shell
+}
+
+// AddShell is a shortcut for adding a command which starts a shell on Stdin and Output
+// Global flags provided with the action are kept for the whole session, ie: `gomu -b JIRA-Ticket shell`
+func (p *Parg) AddShell(action string, usage string) {
+	p.AddHandler(action, func(cmd *Command) (err error) {
+		shell := p.NewShell()
+		for name, flag := range cmd.Flags {
+			shell.session[name] = flag
+		}
+
+		return shell.Run(p.stdin(), p.output())
+	}, usage)
+}
+
+// Run reads and executes lines from in until end of input or exit
+// If in is a terminal, lines are edited in raw mode with tab completion, otherwise they are read as is
+// Command errors are printed to out and do not end the session
+func (s *Shell) Run(in io.Reader, out io.Writer) error {
+	readLine := s.lineReader(in, out)
+	if file, ok := in.(*os.File); ok && isTerminal(file) {
+		readLine = s.terminalReader(file, out)
+	}
+
+	for {
+		line, err := readLine()
+		if err == io.EOF {
+			fmt.Fprintln(out)
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err = s.Exec(line, out); err == errExit {
+			return nil
+		} else if err != nil {
+			fmt.Fprintln(out, "Error: "+err.Error())
+		}
+	}
+}
+
+// lineReader returns a function reading prompted lines from in
+func (s *Shell) lineReader(in io.Reader, out io.Writer) func() (string, error) {
+	reader := bufio.NewReader(in)
+	return func() (string, error) {
+		fmt.Fprint(out, s.Prompt)
+		line, err := reader.ReadString('\n')
+		if err == io.EOF && len(line) > 0 {
+			err = nil
+		}
+
+		return strings.TrimRight(line, "\r\n"), err
+	}
+}
+
+// terminalReader returns a function reading prompted lines from a terminal, edited in raw mode
+// Tab completes the word before the cursor, listing candidates when they share no longer prefix
+// The terminal is restored between lines, so commands write output as usual
+func (s *Shell) terminalReader(file *os.File, out io.Writer) func() (string, error) {
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{file, out}, s.Prompt)
+
+	terminal.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+
+		newLine, newPos, candidates := s.completeLine(line, pos)
+		if newPos == pos && len(candidates) > 1 {
+			fmt.Fprintln(terminal, strings.Join(candidates, "  "))
+		}
+
+		return newLine, newPos, true
+	}
+
+	return func() (string, error) {
+		state, err := term.MakeRaw(int(file.Fd()))
+		if err != nil {
+			return "", err
+		}
+		defer term.Restore(int(file.Fd()), state)
+
+		return terminal.ReadLine()
+	}
+}
+
+// Exec runs a single line, either a builtin or a configured command with session flags applied
+func (s *Shell) Exec(line string, out io.Writer) error {
+	line = strings.TrimSpace(line)
+	if len(line) == 0 {
+		return nil
+	}
+
+	if strings.HasPrefix(line, "!") {
+		// Re-run from history
+		var err error
+		if line, err = s.recall(line); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, line)
+	}
+
+	args, err := Tokenize(line)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "exit", "quit":
+		return errExit
+	case "history":
+		for i, previous := range s.history {
+			fmt.Fprintf(out, "%4d  %s\n", i+1, previous)
+		}
+		return nil
+	}
+
+	s.history = append(s.history, line)
+
+	switch args[0] {
+	case "help":
+		help := NewCommand()
+		help.Action = "help"
+		for _, arg := range args[1:] {
+			help.Arguments = append(help.Arguments, &Argument{Name: arg, Value: arg})
+		}
+		fmt.Fprintln(out, s.parg.commandHelp(help, false))
+		return nil
+	case "set":
+		return s.set(args[1:], out)
+	case "unset":
+		return s.unset(args[1:])
+	}
+
+	cmd, err := s.parg.validate(append(append([]string{os.Args[0]}, s.sessionArgs(args)...), args...))
+	if err != nil {
+		return err
+	}
+
+	return cmd.Exec()
+}
+
+// Complete returns completion candidates for the last word of line
+func (s *Shell) Complete(line string) []string {
+	args, err := Tokenize(line)
+	if err != nil {
+		args = strings.Fields(line)
+	}
+
+	if len(args) == 0 || strings.HasSuffix(line, " ") {
+		// Complete a new word
+		args = append(args, "")
+	}
+
+	candidates := s.parg.Complete(args)
+	if len(args) == 1 {
+		for _, builtin := range shellBuiltins {
+			if strings.HasPrefix(builtin, args[0]) {
+				candidates = append(candidates, builtin)
+			}
+		}
+		candidates = unique(candidates)
+	}
+
+	return candidates
+}
+
+// completeLine completes the word before pos in line, returning the new line and cursor position along with all candidates
+// A single candidate is completed with a trailing space, otherwise the word is extended to the candidates' common prefix
+func (s *Shell) completeLine(line string, pos int) (string, int, []string) {
+	candidates := s.Complete(line[:pos])
+	if len(candidates) == 0 {
+		return line, pos, candidates
+	}
+
+	start := strings.LastIndexAny(line[:pos], " \t") + 1
+	completion := candidates[0] + " "
+	for _, candidate := range candidates[1:] {
+		completion = commonPrefix(completion, candidate)
+	}
+
+	if len(completion) < pos-start {
+		// Candidates may not extend the word, ie: quoted words
+		return line, pos, candidates
+	}
+
+	return line[:start] + completion + line[pos:], start + len(completion), candidates
+}
+
+// commonPrefix returns the longest prefix shared by a and b
+func commonPrefix(a, b string) string {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[:i]
+		}
+	}
+
+	if len(a) < len(b) {
+		return a
+	}
+
+	return b
+}
+
+// recall returns the history line for !<number> or !!
+func (s *Shell) recall(line string) (string, error) {
+	index := len(s.history)
+	if line != "!!" {
+		var err error
+		if index, err = strconv.Atoi(line[1:]); err != nil {
+			return "", fmt.Errorf("invalid history reference <" + line + ">")
+		}
+	}
+
+	if index < 1 || index > len(s.history) {
+		return "", fmt.Errorf("invalid history reference <" + line + ">: no such line")
+	}
+
+	return s.history[index-1], nil
+}
+
+// set parses global flags into the session, or lists session flags if none provided
+func (s *Shell) set(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		names := []string{}
+		for name := range s.session {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if flag := s.session[name]; flag.Sensitive {
+				fmt.Fprintln(out, name+"="+redacted)
+			} else {
+				fmt.Fprintln(out, strings.Join(flagArgs(flag), " "))
+			}
+		}
+		return nil
+	}
+
+	// Only global flags are allowed, none are required
+	var globals Parg
+	for _, flag := range s.parg.GlobalFlags {
+		flag.Required = false
+		globals.GlobalFlags = append(globals.GlobalFlags, flag)
+	}
+
+	cmd, err := globals.validate(append([]string{os.Args[0]}, args...))
+	if err != nil {
+		return err
+	}
+
+	if len(cmd.Arguments) > 0 || len(cmd.Action) > 0 {
+		return fmt.Errorf("set only accepts global flags")
+	}
+
+	for name, flag := range cmd.Flags {
+		s.session[name] = flag
+	}
+
+	return nil
+}
+
+// unset removes session flags by identifier
+func (s *Shell) unset(args []string) error {
+	for _, arg := range args {
+		found := false
+		for name, flag := range s.session {
+			if flag.hasIdentifier(arg) {
+				delete(s.session, name)
+				found = true
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("flag <" + arg + "> is not set")
+		}
+	}
+
+	return nil
+}
+
+// sessionArgs returns args for session flags not overridden by args
+func (s *Shell) sessionArgs(args []string) (sessionArgs []string) {
+	names := []string{}
+	for name := range s.session {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := s.session[name]
+		overridden := false
+		for _, arg := range args {
+			identifier, _, _ := splitFlag(arg)
+			if flag.hasIdentifier(identifier) {
+				overridden = true
+				break
+			}
+		}
+
+		if !overridden {
+			sessionArgs = append(sessionArgs, flagArgs(flag)...)
+		}
+	}
+
+	return
+}
+
+// flagArgs returns args which parse to the flag's value, using -flag=value forms
+func flagArgs(flag *Flag) (args []string) {
+	identifier := flag.Name
+	if len(flag.Identifiers) > 0 {
+		identifier = flag.Identifiers[0]
+	}
+
+	switch value := flag.Value.(type) {
+	case []string:
+		for _, val := range value {
+			args = append(args, identifier+"="+val)
+		}
+	case []int:
+		for _, val := range value {
+			args = append(args, identifier+"="+strconv.Itoa(val))
+		}
+	case map[string]string:
+		for key, val := range value {
+			args = append(args, identifier+"="+key+"="+val)
+		}
+		sort.Strings(args)
+	case map[string]int:
+		for key, val := range value {
+			args = append(args, identifier+"="+key+"="+strconv.Itoa(val))
+		}
+		sort.Strings(args)
+	case map[string]bool:
+		for key, val := range value {
+			args = append(args, identifier+"="+key+"="+strconv.FormatBool(val))
+		}
+		sort.Strings(args)
+	case nil:
+		args = append(args, identifier)
+	default:
+		args = append(args, identifier+"="+fmt.Sprint(value))
+	}
+
+	return
+}
@@ -0,0 +1,145 @@
+package flag
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestShell_Run(context *testing.T) {
+	var calls []string
+	parg := New()
+	parg.AddGlobalFlag(branchConfigFlag)
+	parg.AddHandler(syncAction, func(cmd *Command) error {
+		calls = append(calls, strings.Join(cmd.Args(), ",")+":"+cmd.StringFrom(bFlagName))
+		return nil
+	}, "")
+	parg.AddCommand(Command{Action: "status", Hidden: true})
+
+	input := strings.Join([]string{
+		"set -branch JIRA-Ticket",
+		"sync mod-common",
+		"sync 'mod common' -b other",
+		"!1",
+		"unset -b",
+		"sync simply",
+		"deploy",
+		"history",
+		"exit",
+		"sync ignored",
+	}, "\n")
+
+	var out bytes.Buffer
+	shell := parg.NewShell()
+	err := shell.Run(strings.NewReader(input), &out)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(calls, context, "Handlers should run with session flags")
+	result = test.Equals([]string{
+		"mod-common:JIRA-Ticket",
+		"mod common:other",
+		"simply:",
+	})
+	test.Validate(result)
+
+	output := out.String()
+
+	test = simply.Target(strings.Contains(output, "Error: invalid command <deploy> encountered"), context, "Errors should be printed")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(output, "   4  set -branch JIRA-Ticket\n"), context, "History should include recalled lines")
+	result = test.Equals(true)
+	test.Validate(result)
+}
+
+func TestShell_CompleteLine(context *testing.T) {
+	parg := New()
+	parg.AddGlobalFlag(branchConfigFlag)
+	parg.AddAction(syncAction, "")
+	parg.AddAction("status", "")
+	parg.AddAction("stats", "")
+	parg.AddCommand(Command{Action: "stash", Hidden: true})
+	shell := parg.NewShell()
+
+	line, pos, candidates := shell.completeLine("s", 1)
+
+	test := simply.Target([]interface{}{line, pos, candidates}, context, "Ambiguous words should list candidates, omitting hidden commands")
+	result := test.Equals([]interface{}{"s", 1, []string{"set", "stats", "status", "sync"}})
+	test.Validate(result)
+
+	line, pos, _ = shell.completeLine("sy mod-common", 2)
+
+	test = simply.Target([]interface{}{line, pos}, context, "Single candidates should complete the word before the cursor")
+	result = test.Equals([]interface{}{"sync  mod-common", 5})
+	test.Validate(result)
+
+	line, pos, _ = shell.completeLine("sync -br", 8)
+
+	test = simply.Target([]interface{}{line, pos}, context, "Flags should complete")
+	result = test.Equals([]interface{}{"sync -branch ", 13})
+	test.Validate(result)
+
+	line, pos, _ = shell.completeLine("st", 2)
+
+	test = simply.Target([]interface{}{line, pos}, context, "Ambiguous words should extend to the common prefix")
+	result = test.Equals([]interface{}{"stat", 4})
+	test.Validate(result)
+}
+
+func TestComplete(context *testing.T) {
+	envFlag := Flag{Name: "-env", Identifiers: []string{"-env"}, Choices: []string{"dev", "prod"}}
+
+	parg := New()
+	parg.AddCommand(Command{Action: "status", Aliases: []string{"st"}})
+	parg.AddCommand(Command{Action: syncAction})
+	parg.AddGlobalFlag(nameOnlyConfigFlag)
+	parg.AddGlobalFlag(envFlag)
+
+	test := simply.Target(parg.Complete([]string{"s"}), context, "Commands and aliases should complete")
+	result := test.Equals([]string{"st", "status", "sync"})
+	test.Validate(result)
+
+	test = simply.Target(parg.Complete([]string{"sync", "-n"}), context, "Flags and negations should complete")
+	result = test.Equals([]string{"-name-only", "-no-name-only"})
+	test.Validate(result)
+
+	test = simply.Target(parg.Complete([]string{"sync", "-env", ""}), context, "Choices should complete")
+	result = test.Equals([]string{"dev", "prod"})
+	test.Validate(result)
+
+	test = simply.Target(parg.Complete([]string{"sync", "s"}), context, "Commands should not complete twice")
+	result = test.Equals(nil)
+	test.Validate(result)
+}
+
+func TestShell_AddShell(context *testing.T) {
+	var out bytes.Buffer
+	parg := New()
+	parg.Stdin = strings.NewReader("help sync\nexit\n")
+	parg.Output = &out
+	parg.AddAction(syncAction, "Sync a module")
+	parg.AddShell("shell", "")
+	New().AddAction(deployAction, "")
+
+	command, err := parg.validate(strings.Split("gomu shell", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	err = command.Exec()
+
+	test = simply.Target(err, context, "Error should not exist")
+	result = test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(out.String(), "Command: sync") && strings.Contains(out.String(), "Sync a module"), context, "Help should be written to Output from the shell's parg")
+	result = test.Equals(true)
+	test.Validate(result)
+}
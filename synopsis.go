@@ -0,0 +1,146 @@
+package flag
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// Synopsis returns a usage line for the command, ie: `gomu sync <module>... [-b <branch>] [-name-only]`
+// Derived from the configured command's arguments and flags, along with global flags
+func (cmd *Command) Synopsis() string {
+	static := getStaticParg()
+	if static == nil {
+		static = &Parg{}
+	}
+
+	return static.commandSynopsis(cmd)
+}
+
+// commandSynopsis returns a usage line for the command, from its definition on p if configured
+func (p *Parg) commandSynopsis(cmd *Command) string {
+	if definition := p.command(cmd.Action); definition != nil {
+		return p.synopsis(definition, true)
+	}
+
+	return p.synopsis(cmd, true)
+}
+
+// synopsis returns a usage line for the command definition, optionally including global flags
+func (p *Parg) synopsis(cmd *Command, globals bool) string {
+	words := []string{os.Args[0]}
+	if len(cmd.Action) > 0 {
+		words = append(words, cmd.Action)
+	}
+
+	for _, argument := range cmd.Arguments {
+		words = append(words, argument.synopsis())
+	}
+
+	for i := range p.GlobalFlags {
+		if flag := &p.GlobalFlags[i]; globals && !flag.Hidden {
+			words = append(words, flag.synopsis())
+		}
+	}
+
+	for _, name := range sortedFlagNames(cmd.Flags) {
+		if flag := cmd.Flags[name]; !flag.Hidden {
+			words = append(words, flag.synopsis())
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// sortedFlagNames returns the keys of flags in order
+func sortedFlagNames(flags map[string]*Flag) (names []string) {
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return
+}
+
+// usage returns the command's synopsis for error messages
+func (p *Parg) usage(cmd *Command) string {
+	if cmd == nil {
+		return ""
+	}
+
+	return "\nUsage: " + p.synopsis(cmd, true)
+}
+
+// synopsis returns the argument's usage, ie: <module>, [<module>] or <module>...
+func (arg *Argument) synopsis() string {
+	usage := "<" + arg.Name + ">"
+	if len(arg.Choices) > 0 {
+		usage = "<" + strings.Join(arg.Choices, "|") + ">"
+	}
+
+	if arg.Type.isVariadic() {
+		usage += "..."
+	}
+
+	if !arg.Required {
+		usage = "[" + usage + "]"
+	}
+
+	return usage
+}
+
+// synopsis returns the flag's usage, ie: [-b <branch>], [-v...] or -i <include>...
+func (flag *Flag) synopsis() string {
+	identifier := flag.Name
+	if len(flag.Identifiers) > 0 {
+		identifier = flag.Identifiers[0]
+	}
+
+	usage := identifier
+	switch flag.Type {
+	case BOOL:
+	case COUNT:
+		usage += "..."
+	default:
+		usage += " " + flag.placeholder()
+	}
+
+	if !flag.Required {
+		usage = "[" + usage + "]"
+	}
+
+	return usage
+}
+
+// placeholder returns a description of the flag's expected values based on type, ie: <branch>, <int>...
+func (flag *Flag) placeholder() (placeholder string) {
+	switch flag.Type {
+	case INT, INTS:
+		placeholder = "<int>"
+	case MAP:
+		placeholder = "<key=value>"
+	case INTMAP:
+		placeholder = "<key=int>"
+	case BOOLMAP:
+		placeholder = "<key=bool>"
+	default:
+		// Use the longest identifier as a name, ie: -b, -branch => <branch>
+		name := strings.TrimLeft(flag.Name, "-")
+		for _, identifier := range flag.Identifiers {
+			if trimmed := strings.TrimLeft(identifier, "-"); len(trimmed) > len(name) {
+				name = trimmed
+			}
+		}
+		placeholder = "<" + name + ">"
+	}
+
+	if len(flag.Choices) > 0 {
+		placeholder = "<" + strings.Join(flag.Choices, "|") + ">"
+	}
+
+	if flag.Type.isVariadic() {
+		placeholder += "..."
+	}
+
+	return
+}
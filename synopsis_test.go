@@ -0,0 +1,58 @@
+package flag
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestSynopsis(context *testing.T) {
+	hiddenFlag := nameOnlyConfigFlag
+	hiddenFlag.Name = "-debug"
+	hiddenFlag.Identifiers = []string{"-debug"}
+	hiddenFlag.Hidden = true
+
+	parg := New()
+	parg.AddCommand(Command{
+		Action: syncAction,
+		Arguments: []*Argument{
+			{Name: "module", Type: STRINGS, Required: true},
+		},
+	})
+	parg.AddCommand(Command{
+		Action: deployAction,
+		Arguments: []*Argument{
+			{Name: "env", Required: true, Choices: []string{"dev", "prod"}},
+			{Name: "replicas", Type: INT},
+		},
+	})
+	parg.AddGlobalFlag(branchConfigFlag)
+	parg.AddGlobalFlag(nameOnlyConfigFlag)
+	parg.AddGlobalFlag(vConfigFlag)
+	parg.AddGlobalFlag(labelConfigFlag)
+	parg.AddGlobalFlag(hiddenFlag)
+
+	synopsis := (&Command{Action: syncAction}).Synopsis()
+
+	test := simply.Target(strings.TrimPrefix(synopsis, os.Args[0]+" "), context, "Sync synopsis should describe args and flags")
+	result := test.Equals("sync <module>... [-b <branch>] [-name-only] [-v...] [-label <key=value>...]")
+	test.Validate(result)
+
+	synopsis = (&Command{Action: deployAction}).Synopsis()
+
+	test = simply.Target(strings.TrimPrefix(synopsis, os.Args[0]+" "), context, "Deploy synopsis should describe choices and optional args")
+	result = test.Equals("deploy <dev|prod> [<replicas>] [-b <branch>] [-name-only] [-v...] [-label <key=value>...]")
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(Help(false), " sync <module>...\n"), context, "Help should include synopsis")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	_, err := parg.validate(strings.Split("gomu deploy prod 3 extra", " "))
+
+	test = simply.Target(strings.HasSuffix(err.Error(), "\nUsage: "+(&Command{Action: deployAction}).Synopsis()), context, "Errors should include synopsis")
+	result = test.Equals(true)
+	test.Validate(result)
+}
@@ -0,0 +1,90 @@
+package flag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tokenize splits a command line into arguments using POSIX shell-style quoting and escapes,
+// ie: `deploy "mod common" -m 'all done'` returns [deploy, mod common, -m, all done]
+// Returns an error for unterminated quotes or a trailing escape
+func Tokenize(line string) ([]string, error) {
+	return tokenize(line, false)
+}
+
+// tokenize splits input into words using shell-like quoting:
+// Single quotes preserve everything literally, double quotes allow backslash escapes of \ " $ and `
+// Outside of quotes, backslash escapes the next character and whitespace separates words
+// When comments is set, # at the start of a word ignores the rest of the line
+func tokenize(input string, comments bool) (words []string, err error) {
+	var word strings.Builder
+	var inWord bool
+	var quote rune
+	var quoteStart int
+	var escaped bool
+	var inComment bool
+
+	for index, char := range input {
+		if inComment {
+			// Skip to end of line
+			inComment = char != '\n'
+			continue
+		}
+
+		switch {
+		case escaped:
+			if quote == '"' && !strings.ContainsRune("\\\"$`\n", char) {
+				// Backslash is literal in double quotes unless escaping a special char
+				word.WriteRune('\\')
+			}
+			if char != '\n' {
+				// Escaped newlines continue the line, without starting a word
+				word.WriteRune(char)
+				inWord = true
+			}
+			escaped = false
+		case quote == '\'':
+			if char == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(char)
+			}
+		case char == '\\':
+			escaped = true
+		case quote == '"':
+			if char == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(char)
+			}
+		case char == '\'' || char == '"':
+			quote = char
+			quoteStart = index
+			inWord = true
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case char == '#' && comments && !inWord:
+			inComment = true
+		default:
+			word.WriteRune(char)
+			inWord = true
+		}
+	}
+
+	switch {
+	case escaped:
+		return nil, fmt.Errorf("unterminated escape at end of input")
+	case quote == '\'' || quote == '"':
+		return nil, fmt.Errorf("unterminated %c quote at position %d", quote, quoteStart)
+	}
+
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return
+}
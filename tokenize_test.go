@@ -0,0 +1,75 @@
+package flag
+
+import (
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestTokenize(context *testing.T) {
+	words, err := Tokenize(`sync "mod common" -b JIRA\ Ticket #general`)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(words, context, "Words should not treat # as a comment")
+	result = test.Equals([]string{"sync", "mod common", "-b", "JIRA Ticket", "#general"})
+	test.Validate(result)
+
+	_, err = Tokenize(`sync -b "JIRA`)
+
+	test = simply.Target(err, context, "Error should name the unterminated quote")
+	result = test.Equals(`unterminated " quote at position 8`)
+	test.Validate(result)
+}
+
+func TestTokenize_Comments(context *testing.T) {
+	words, err := tokenize(`sync "mod common" 'it''s' a\ b "say \"hi\"" '' # comment`, true)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(words, context, "Words should respect quotes and escapes")
+	result = test.Equals([]string{"sync", "mod common", "its", "a b", `say "hi"`, ""})
+	test.Validate(result)
+
+	for _, input := range []string{`sync "mod`, `sync 'mod`, `sync mod\`} {
+		_, err = tokenize(input, false)
+
+		test = simply.Target(err, context, "Error should exist for <"+input+">")
+		result = test.DoesNotEqual(nil)
+		test.Validate(result)
+	}
+}
+
+func TestParseLine(context *testing.T) {
+	parg := New()
+	parg.AddAction(syncAction, "")
+	parg.AddGlobalFlag(branchConfigFlag)
+
+	command, err := parg.ParseLine(`sync "mod common" -branch 'JIRA Ticket'`)
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Args(), context, "Quoted argument should be a single arg")
+	result = test.Equals([]string{"mod common"})
+	test.Validate(result)
+
+	test = simply.Target(command.StringFrom(bFlagName), context, "Quoted flag value should be preserved")
+	result = test.Equals("JIRA Ticket")
+	test.Validate(result)
+
+	command, err = parg.ParseLine(`sync 'mod common`)
+
+	test = simply.Target(err, context, "Unterminated quote should error")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+
+	test = simply.Target(command, context, "Command should not exist")
+	result = simply.Assert(test).Equals(nil)
+	test.Validate(result)
+}
@@ -0,0 +1,158 @@
+package flag
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// errMalformedPair is returned by addPair for values without a key
+	errMalformedPair = errors.New("expected key=value")
+	// errDuplicateKey is returned by addPair for keys already set
+	errDuplicateKey = errors.New("duplicate key")
+	// errPairValue is returned by addPair for values which don't match the map type
+	errPairValue = errors.New("invalid value")
+)
+
+// parseBool parses explicit boolean values, including yes/no and on/off
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y", "on":
+		return true, nil
+	case "no", "n", "off":
+		return false, nil
+	}
+
+	return strconv.ParseBool(value)
+}
+
+// addPair adds a key=value pair to the current map value of a MAP, INTMAP or BOOLMAP type
+// Returns the updated map, or an error for malformed pairs, duplicate keys and invalid values
+func addPair(argType ArgType, current interface{}, pair string) (interface{}, error) {
+	index := strings.Index(pair, "=")
+	if index < 1 {
+		return current, errMalformedPair
+	}
+
+	key, val := pair[:index], pair[index+1:]
+	if hasKey(current, key) {
+		return current, errDuplicateKey
+	}
+
+	switch argType {
+	case MAP:
+		m, ok := current.(map[string]string)
+		if !ok {
+			m = map[string]string{}
+		}
+		m[key] = val
+		return m, nil
+	case INTMAP:
+		num, err := strconv.Atoi(val)
+		if err != nil {
+			return current, errPairValue
+		}
+
+		m, ok := current.(map[string]int)
+		if !ok {
+			m = map[string]int{}
+		}
+		m[key] = num
+		return m, nil
+	case BOOLMAP:
+		b, err := parseBool(val)
+		if err != nil {
+			return current, errPairValue
+		}
+
+		m, ok := current.(map[string]bool)
+		if !ok {
+			m = map[string]bool{}
+		}
+		m[key] = b
+		return m, nil
+	}
+
+	return current, errPairValue
+}
+
+// pairKey returns the key of a key=value pair
+func pairKey(pair string) string {
+	if index := strings.Index(pair, "="); index >= 0 {
+		return pair[:index]
+	}
+
+	return pair
+}
+
+// hasKey returns true if a map value already contains key
+func hasKey(current interface{}, key string) (ok bool) {
+	switch m := current.(type) {
+	case map[string]string:
+		_, ok = m[key]
+	case map[string]int:
+		_, ok = m[key]
+	case map[string]bool:
+		_, ok = m[key]
+	}
+
+	return
+}
+
+// copyStrings returns a copy of values, or nil if values is nil
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	return append([]string{}, values...)
+}
+
+// copyValue returns a copy of slice and map values, so parsed values don't share them with definitions
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []string:
+		return copyStrings(v)
+	case []int:
+		return append([]int{}, v...)
+	case map[string]string:
+		m := make(map[string]string, len(v))
+		for key, val := range v {
+			m[key] = val
+		}
+		return m
+	case map[string]int:
+		m := make(map[string]int, len(v))
+		for key, val := range v {
+			m[key] = val
+		}
+		return m
+	case map[string]bool:
+		m := make(map[string]bool, len(v))
+		for key, val := range v {
+			m[key] = val
+		}
+		return m
+	}
+
+	return value
+}
+
+// isValue returns true if value has the type parsed for argType, ie: []int for INTS
+func isValue(argType ArgType, value interface{}) bool {
+	return fmt.Sprintf("%T", value) == valueType(argType)
+}
+
+// valueType returns the name of the Go type parsed for argType, which matches argType other than for DEFAULT and COUNT
+func valueType(argType ArgType) string {
+	switch argType {
+	case DEFAULT:
+		return "string"
+	case COUNT:
+		return "int"
+	}
+
+	return string(argType)
+}
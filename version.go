@@ -0,0 +1,138 @@
+package flag
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	buildinfo "runtime/debug"
+)
+
+// VersionInfo describes the program version and build
+type VersionInfo struct {
+	// Version configured by AddVersion, or the main module version if not set
+	Version string `json:"version,omitempty"`
+	// Path of the main module
+	Path string `json:"path,omitempty"`
+	// Revision of the VCS commit the program was built from
+	Revision string `json:"revision,omitempty"`
+	// Time of the VCS commit the program was built from
+	Time string `json:"time,omitempty"`
+	// Dirty indicates the program was built with uncommitted changes
+	Dirty bool `json:"dirty,omitempty"`
+	// GoVersion used to build the program
+	GoVersion string `json:"goVersion,omitempty"`
+}
+
+// String returns version info as plain text
+func (info VersionInfo) String() string {
+	msg := filepath.Base(os.Args[0])
+	if len(info.Version) > 0 {
+		msg += " " + info.Version
+	}
+	msg += "\n"
+
+	if len(info.Path) > 0 {
+		msg += "  path: " + info.Path + "\n"
+	}
+	if len(info.Revision) > 0 {
+		msg += "  revision: " + info.Revision
+		if info.Dirty {
+			msg += " (dirty)"
+		}
+		msg += "\n"
+	}
+	if len(info.Time) > 0 {
+		msg += "  time: " + info.Time + "\n"
+	}
+	if len(info.GoVersion) > 0 {
+		msg += "  go: " + info.GoVersion + "\n"
+	}
+
+	return msg
+}
+
+// AddVersion registers a `version` action and `-version` global flag printing version along with build info
+// `version json` prints build info as JSON
+func (p *Parg) AddVersion(version string) {
+	p.version = version
+	p.versioned = true
+
+	var command Command
+	command.Action = "version"
+	command.Arguments = []*Argument{{Name: "format", Choices: []string{"text", "json"}}}
+	command.handler = p.printVersion
+	command.helpDetails = "Print version and build info, optionally as json"
+	p.AddCommand(command)
+
+	p.AddGlobalFlag(Flag{
+		Name:        "-version",
+		Identifiers: []string{"-version"},
+		Type:        BOOL,
+		Help:        "Print version and build info",
+	})
+}
+
+// VersionInfo returns the configured version along with build info
+func (p *Parg) VersionInfo() (info VersionInfo) {
+	info.Version = p.version
+
+	build, ok := buildinfo.ReadBuildInfo()
+	if !ok {
+		return
+	}
+
+	info.Path = build.Main.Path
+	info.GoVersion = build.GoVersion
+	if len(info.Version) == 0 && build.Main.Version != "(devel)" {
+		info.Version = build.Main.Version
+	}
+
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.Time = setting.Value
+		case "vcs.modified":
+			info.Dirty = setting.Value == "true"
+		}
+	}
+
+	return
+}
+
+// versionCommand returns the version command, used when -version short-circuits parsing
+func (p *Parg) versionCommand() *Command {
+	command := NewCommand()
+	command.Action = "version"
+	command.handler = p.printVersion
+	if definition, ok := p.GetAllowedCommands()["version"]; ok {
+		command.helpDetails = definition.helpDetails
+		command.hooks = p.hooks.with(definition)
+	}
+
+	return command
+}
+
+// isVersionFlag returns true if flag is the -version flag registered by AddVersion, and is set
+func (p *Parg) isVersionFlag(flag *Flag) bool {
+	return p.versioned && flag.Name == "-version" && flag.Value == true
+}
+
+// printVersion writes version info to Output as text, or json if requested
+func (p *Parg) printVersion(cmd *Command) (err error) {
+	info := p.VersionInfo()
+	if len(cmd.Arguments) > 0 && cmd.Arguments[0].Value == "json" {
+		var data []byte
+		if data, err = json.MarshalIndent(info, "", "  "); err != nil {
+			return
+		}
+
+		_, err = fmt.Fprintln(p.output(), string(data))
+		return
+	}
+
+	_, err = fmt.Fprint(p.output(), info.String())
+	return
+}
@@ -0,0 +1,74 @@
+package flag
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/simply"
+)
+
+func TestVersion_Flag(context *testing.T) {
+	var out bytes.Buffer
+	parg := New()
+	parg.Output = &out
+	parg.AddAction(syncAction, "")
+	parg.AddVersion("v1.2.3")
+
+	command, err := parg.validate(strings.Split("gomu sync -version -unknown", " "))
+
+	test := simply.Target(err, context, "Version flag should short-circuit parsing")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(command.Action, context, "Action should be <version>")
+	result = test.Equals("version")
+	test.Validate(result)
+
+	err = command.Exec()
+
+	test = simply.Target(err, context, "Exec error should not exist")
+	result = test.Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(strings.HasPrefix(out.String(), parg.VersionInfo().String()), context, "Version should be printed")
+	result = test.Equals(true)
+	test.Validate(result)
+
+	test = simply.Target(strings.Contains(out.String(), " v1.2.3\n"), context, "Version should contain configured version")
+	result = test.Equals(true)
+	test.Validate(result)
+}
+
+func TestVersion_JSON(context *testing.T) {
+	var out bytes.Buffer
+	parg := New()
+	parg.Output = &out
+	parg.AddVersion("v1.2.3")
+
+	command, err := parg.validate(strings.Split("gomu version json", " "))
+
+	test := simply.Target(err, context, "Error should not exist")
+	result := test.Assert().Equals(nil)
+	test.Validate(result)
+
+	command.Exec()
+
+	var info VersionInfo
+	err = json.Unmarshal(out.Bytes(), &info)
+
+	test = simply.Target(err, context, "Output should be JSON")
+	result = test.Equals(nil)
+	test.Validate(result)
+
+	test = simply.Target(info, context, "JSON should match version info")
+	result = test.Equals(parg.VersionInfo())
+	test.Validate(result)
+
+	_, err = parg.validate(strings.Split("gomu version yaml", " "))
+
+	test = simply.Target(err, context, "Unknown format should error")
+	result = test.DoesNotEqual(nil)
+	test.Validate(result)
+}